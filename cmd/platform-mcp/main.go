@@ -2,30 +2,270 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+	"text/tabwriter"
 
+	sdkauth "github.com/modelcontextprotocol/go-sdk/auth"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/internal/auth"
+	"github.com/modelcontextprotocol/platform.mcp/internal/config"
+	"github.com/modelcontextprotocol/platform.mcp/internal/logging"
 	internalmcp "github.com/modelcontextprotocol/platform.mcp/internal/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/internal/templates"
 )
 
 func main() {
-	if err := run(); err != nil {
+	if err := run(os.Args[1:]); err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
-func run() error {
-	ctx := context.Background()
+// version is set at build time with -ldflags "-X main.version=<version>".
+var version = "dev"
 
-	// 1. Initialize MCP server
-	server := internalmcp.NewServer("0.1.0")
+func run(args []string) error {
+	if len(args) > 0 && args[0] == "tools" {
+		return runTools(args[1:], os.Stdout)
+	}
+
+	// Flag defaults mirror config.Default; only flags set on the command line
+	// override the config file and environment, see loadConfig.
+	defaults := config.Default()
+	flags := defaults
+	fs := flag.NewFlagSet("platform-mcp", flag.ContinueOnError)
+	configFile := fs.String("config", "", "YAML server configuration file (default $"+config.EnvConfig+")")
+	showVersion := fs.Bool("version", false, "Print the version and exit")
+	fs.StringVar(&flags.Transport, "transport", defaults.Transport, "Transport to serve on (stdio, http)")
+	fs.StringVar(&flags.Addr, "addr", defaults.Addr, "Listen address for the http transport")
+	fs.BoolVar(&flags.Stateless, "stateless", defaults.Stateless, "Disable session tracking for the http transport")
+	fs.DurationVar(&flags.SessionTimeout, "session-timeout", defaults.SessionTimeout, "Close idle http sessions after this duration (0 disables)")
+	fs.StringVar(&flags.LogLevel, "log-level", defaults.LogLevel, "Minimum level of logs written to stderr (debug, info, warn, error)")
+	templateDirs := fs.String("templates", "", "Comma-separated external template directories, searched in order before the embedded templates")
+	fs.DurationVar(&flags.ReloadInterval, "reload-interval", defaults.ReloadInterval, "How often to check the template directories for changes (0 disables reloading)")
+	tools := fs.String("tools", "", "Comma-separated tools to register (default: every tool that is not opt-in)")
+	enableApply := fs.Bool("enable-apply", false, "Register the apply tool, which writes files inside the client's MCP roots")
+	fs.StringVar(&flags.WorkspaceRoot, "workspace-root", "", "Server directory that the client's MCP roots must lie in for apply and the tools that read them; required for those tools over http")
+	fs.StringVar(&flags.Defaults.Org, "default-org", "", "GitHub organization used when a tool call sets none")
+	fs.StringVar(&flags.Defaults.Registry, "default-registry", "", "Container registry used when a tool call sets none")
+	fs.StringVar(&flags.Defaults.Branch, "default-branch", "", "Branch used when a tool call sets none")
+	fs.StringVar(&flags.Defaults.WorkflowType, "default-workflow-type", "", "Workflow type used when a tool call sets none (default go)")
+	fs.StringVar(&flags.Auth.Mode, "auth", defaults.Auth.Mode, "Authentication for the http transport (none, tokens, jwt)")
+	fs.StringVar(&flags.Auth.TokensFile, "auth-tokens-file", "", "YAML file of static bearer tokens (--auth=tokens)")
+	fs.StringVar(&flags.Auth.JWKSFile, "auth-jwks-file", "", "Local JWKS file used to verify JWTs (--auth=jwt)")
+	fs.StringVar(&flags.Auth.Issuer, "auth-issuer", "", "Required JWT issuer (--auth=jwt)")
+	fs.StringVar(&flags.Auth.Audience, "auth-audience", "", "Required JWT audience (--auth=jwt)")
+	fs.StringVar(&flags.Auth.PolicyFile, "auth-policy-file", "", "YAML policy mapping identities to tools and templates (default: allow all authenticated callers)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *showVersion {
+		fmt.Println(version)
+		return nil
+	}
+	flags.Templates = config.SplitList(*templateDirs)
+	flags.Tools = config.SplitList(*tools)
+
+	cfg, err := loadConfig(fs, *configFile, flags)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(internalmcp.ToolNames()); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	level, _ := logging.ParseLevel(cfg.LogLevel)
+	logger := logging.New(os.Stderr, level)
+	if err := templates.SetLayers(cfg.Templates); err != nil {
+		return fmt.Errorf("invalid templates: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// 2. Register tools
-	internalmcp.RegisterTools(server)
+	// 1. Resolve authentication; only the http transport supports it
+	var verifier sdkauth.TokenVerifier
+	var policy *auth.Policy
+	if cfg.Transport == "http" {
+		verifier, err = newVerifier(cfg.Auth.Mode, cfg.Auth.TokensFile, cfg.Auth.JWKSFile, auth.JWTOptions{
+			Issuer:   cfg.Auth.Issuer,
+			Audience: cfg.Auth.Audience,
+		})
+		if err != nil {
+			return err
+		}
+		if verifier != nil {
+			policy = auth.AllowAll()
+			if cfg.Auth.PolicyFile != "" {
+				if policy, err = auth.LoadPolicy(cfg.Auth.PolicyFile); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// 2. Initialize MCP server
+	server := internalmcp.NewServer(version, &internalmcp.ServerOptions{
+		Logger:   logger,
+		Policy:   policy,
+		Defaults: cfg.Defaults.Config(),
+		// Clients of the http transport may run on another machine, so the
+		// server does not trust their roots beyond its workspace root.
+		WorkspaceRoot: cfg.WorkspaceRoot,
+		RemoteClients: cfg.Transport == "http",
+	})
+
+	// 3. Register tools
+	if len(cfg.Tools) > 0 {
+		if err := internalmcp.EnableTools(server, cfg.Tools); err != nil {
+			return err
+		}
+	} else {
+		internalmcp.RegisterTools(server)
+	}
+	if *enableApply && !slices.Contains(cfg.Tools, "apply") {
+		internalmcp.RegisterApplyTool(server)
+	}
+	resources, err := internalmcp.RegisterTemplateResources(ctx, server)
+	if err != nil {
+		return err
+	}
+
+	// 4. Reload external templates when they change
+	if len(cfg.Templates) > 0 && cfg.ReloadInterval > 0 {
+		watcher := &templates.Watcher{
+			Dirs:     cfg.Templates,
+			Interval: cfg.ReloadInterval,
+			OnReload: func(ctx context.Context, _ *templates.Snapshot) {
+				if err := resources.Refresh(ctx); err != nil {
+					logger.Warn("failed to refresh template resources", "error", err)
+				}
+			},
+		}
+		go watcher.Run(logging.WithLogger(ctx, logger))
+	}
 
-	// 3. Start server with stdio transport
-	fmt.Fprintf(os.Stderr, "platform-mcp server starting...\n")
-	return server.Run(ctx, &mcp.StdioTransport{})
+	// 5. Start server with the selected transport
+	if cfg.Transport == "stdio" {
+		logger.Info("platform-mcp server starting", "version", version, "transport", "stdio")
+		return server.Run(ctx, &mcp.StdioTransport{})
+	}
+	opts := internalmcp.HTTPOptions{
+		Addr:           cfg.Addr,
+		Stateless:      cfg.Stateless,
+		SessionTimeout: cfg.SessionTimeout,
+		Verifier:       verifier,
+	}
+	logger.Info("platform-mcp server listening", "version", version, "transport", "http", "addr", opts.Addr, "auth", cfg.Auth.Mode)
+	return internalmcp.ServeHTTP(ctx, internalmcp.NewHTTPHandler(server, opts), opts)
+}
+
+// loadConfig layers the server settings: built-in defaults, then the config
+// file (path, or $PLATFORM_MCP_CONFIG), then PLATFORM_MCP_* variables, then
+// the flags set on the command line, whose values are taken from flags.
+func loadConfig(fs *flag.FlagSet, path string, flags config.Server) (config.Server, error) {
+	cfg := config.Default()
+	if path == "" {
+		path = os.Getenv(config.EnvConfig)
+	}
+	if path != "" {
+		if err := cfg.Load(path); err != nil {
+			return cfg, err
+		}
+	}
+	if err := cfg.ApplyEnv(os.LookupEnv); err != nil {
+		return cfg, err
+	}
+
+	overrides := map[string]func(){
+		"transport":             func() { cfg.Transport = flags.Transport },
+		"addr":                  func() { cfg.Addr = flags.Addr },
+		"stateless":             func() { cfg.Stateless = flags.Stateless },
+		"session-timeout":       func() { cfg.SessionTimeout = flags.SessionTimeout },
+		"log-level":             func() { cfg.LogLevel = flags.LogLevel },
+		"templates":             func() { cfg.Templates = flags.Templates },
+		"reload-interval":       func() { cfg.ReloadInterval = flags.ReloadInterval },
+		"tools":                 func() { cfg.Tools = flags.Tools },
+		"workspace-root":        func() { cfg.WorkspaceRoot = flags.WorkspaceRoot },
+		"default-org":           func() { cfg.Defaults.Org = flags.Defaults.Org },
+		"default-registry":      func() { cfg.Defaults.Registry = flags.Defaults.Registry },
+		"default-branch":        func() { cfg.Defaults.Branch = flags.Defaults.Branch },
+		"default-workflow-type": func() { cfg.Defaults.WorkflowType = flags.Defaults.WorkflowType },
+		"auth":                  func() { cfg.Auth.Mode = flags.Auth.Mode },
+		"auth-tokens-file":      func() { cfg.Auth.TokensFile = flags.Auth.TokensFile },
+		"auth-jwks-file":        func() { cfg.Auth.JWKSFile = flags.Auth.JWKSFile },
+		"auth-issuer":           func() { cfg.Auth.Issuer = flags.Auth.Issuer },
+		"auth-audience":         func() { cfg.Auth.Audience = flags.Auth.Audience },
+		"auth-policy-file":      func() { cfg.Auth.PolicyFile = flags.Auth.PolicyFile },
+	}
+	fs.Visit(func(f *flag.Flag) {
+		if override, ok := overrides[f.Name]; ok {
+			override()
+		}
+	})
+	return cfg, nil
+}
+
+// runTools prints the tool catalog, including opt-in tools.
+func runTools(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("platform-mcp tools", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "Print the catalog as JSON, with input and output schemas")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	entries, err := internalmcp.ListCatalog(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(map[string]any{"tools": entries})
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tVERSION\tREAD-ONLY\tDESTRUCTIVE\tIDEMPOTENT\tOPT-IN\tTITLE")
+	for _, e := range entries {
+		a := e.Annotations
+		fmt.Fprintf(tw, "%s\t%s\t%t\t%t\t%t\t%t\t%s\n", e.Name, e.Version, a.ReadOnlyHint, *a.DestructiveHint, a.IdempotentHint, e.OptIn, e.Title)
+	}
+	return tw.Flush()
+}
+
+// newVerifier builds the bearer token verifier for the selected auth mode.
+// It returns nil when authentication is disabled.
+func newVerifier(mode, tokensFile, jwksFile string, jwtOpts auth.JWTOptions) (sdkauth.TokenVerifier, error) {
+	switch mode {
+	case "none":
+		return nil, nil
+	case "tokens":
+		if tokensFile == "" {
+			return nil, fmt.Errorf("a tokens file (--auth-tokens-file) is required with --auth=tokens")
+		}
+		tokens, err := auth.LoadStaticTokens(tokensFile)
+		if err != nil {
+			return nil, err
+		}
+		return auth.NewStaticTokenVerifier(tokens), nil
+	case "jwt":
+		if jwksFile == "" {
+			return nil, fmt.Errorf("a JWKS file (--auth-jwks-file) is required with --auth=jwt")
+		}
+		keys, err := auth.LoadJWKS(jwksFile)
+		if err != nil {
+			return nil, err
+		}
+		return auth.NewJWTVerifier(keys, jwtOpts), nil
+	default:
+		return nil, fmt.Errorf("unsupported auth mode %q (expected none, tokens or jwt)", mode)
+	}
 }
@@ -0,0 +1,124 @@
+// Package archive packs generated scaffold files into a single stream, a tar
+// or zip archive or a multi-document text stream, for the CLI to write to a
+// file or stdout and for the MCP server to return as a resource.
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+)
+
+// Archive formats.
+const (
+	FormatTar      = "tar"
+	FormatZip      = "zip"
+	FormatMultiDoc = "multidoc"
+)
+
+// Formats lists the accepted archive formats.
+var Formats = []string{FormatTar, FormatZip, FormatMultiDoc}
+
+// modTime is the modification time of every archived file, so that the same
+// files always produce the same archive. Zip cannot store dates before 1980.
+var modTime = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Write writes files to w in format. Tar and zip entries keep the file
+// modes; the multi-document stream separates files with a
+// "--- FILE: <path> ---" line, like the generate MCP tool.
+func Write(w io.Writer, format string, files []scaffold.File) error {
+	switch format {
+	case FormatTar:
+		return writeTar(w, files)
+	case FormatZip:
+		return writeZip(w, files)
+	case FormatMultiDoc:
+		return writeMultiDoc(w, files)
+	default:
+		return fmt.Errorf("unsupported archive format %q (%s)", format, strings.Join(Formats, ", "))
+	}
+}
+
+// MIMEType returns the media type of archives in format.
+func MIMEType(format string) string {
+	switch format {
+	case FormatTar:
+		return "application/x-tar"
+	case FormatZip:
+		return "application/zip"
+	default:
+		return "text/plain"
+	}
+}
+
+// Extension returns the file name extension of archives in format.
+func Extension(format string) string {
+	if format == FormatMultiDoc {
+		return ".txt"
+	}
+	return "." + format
+}
+
+func writeTar(w io.Writer, files []scaffold.File) error {
+	tw := tar.NewWriter(w)
+	for _, f := range files {
+		hdr := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     f.Path,
+			Mode:     int64(mode(f)),
+			Size:     int64(len(f.Content)),
+			ModTime:  modTime,
+			Format:   tar.FormatPAX,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("failed to archive %s: %w", f.Path, err)
+		}
+		if _, err := io.WriteString(tw, f.Content); err != nil {
+			return fmt.Errorf("failed to archive %s: %w", f.Path, err)
+		}
+	}
+	return tw.Close()
+}
+
+func writeZip(w io.Writer, files []scaffold.File) error {
+	zw := zip.NewWriter(w)
+	for _, f := range files {
+		hdr := &zip.FileHeader{Name: f.Path, Method: zip.Deflate, Modified: modTime}
+		hdr.SetMode(mode(f))
+		fw, err := zw.CreateHeader(hdr)
+		if err != nil {
+			return fmt.Errorf("failed to archive %s: %w", f.Path, err)
+		}
+		if _, err := io.WriteString(fw, f.Content); err != nil {
+			return fmt.Errorf("failed to archive %s: %w", f.Path, err)
+		}
+	}
+	return zw.Close()
+}
+
+func writeMultiDoc(w io.Writer, files []scaffold.File) error {
+	for _, f := range files {
+		content := f.Content
+		if content != "" && !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		if _, err := fmt.Fprintf(w, "--- FILE: %s ---\n%s", f.Path, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mode returns the permission bits of f, defaulting to 0644.
+func mode(f scaffold.File) fs.FileMode {
+	if f.Mode == 0 {
+		return 0644
+	}
+	return fs.FileMode(f.Mode).Perm()
+}
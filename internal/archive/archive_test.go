@@ -0,0 +1,107 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"io"
+	"io/fs"
+	"testing"
+
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+)
+
+var files = []scaffold.File{
+	{Path: "Dockerfile", Content: "FROM scratch\n", Mode: 0644},
+	{Path: "scripts/build.sh", Content: "#!/bin/sh\n", Mode: 0755},
+}
+
+func TestWrite_Tar(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatTar, files); err != nil {
+		t.Fatal(err)
+	}
+
+	tr := tar.NewReader(&buf)
+	for _, want := range files {
+		hdr, err := tr.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Name != want.Path || string(content) != want.Content || hdr.Mode != int64(want.Mode) {
+			t.Errorf("entry %s (%o) = %q, want %s (%o) = %q", hdr.Name, hdr.Mode, content, want.Path, want.Mode, want.Content)
+		}
+	}
+	if _, err := tr.Next(); err != io.EOF {
+		t.Errorf("expected %d entries, got more (err %v)", len(files), err)
+	}
+}
+
+func TestWrite_Zip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatZip, files); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(zr.File) != len(files) {
+		t.Fatalf("expected %d entries, got %d", len(files), len(zr.File))
+	}
+	for i, zf := range zr.File {
+		want := files[i]
+		r, err := zf.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if zf.Name != want.Path || string(content) != want.Content || zf.Mode() != fs.FileMode(want.Mode) {
+			t.Errorf("entry %s (%v) = %q, want %s (%v) = %q", zf.Name, zf.Mode(), content, want.Path, fs.FileMode(want.Mode), want.Content)
+		}
+	}
+}
+
+func TestWrite_Reproducible(t *testing.T) {
+	for _, format := range []string{FormatTar, FormatZip} {
+		var a, b bytes.Buffer
+		if err := Write(&a, format, files); err != nil {
+			t.Fatal(err)
+		}
+		if err := Write(&b, format, files); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(a.Bytes(), b.Bytes()) {
+			t.Errorf("%s archives of the same files differ", format)
+		}
+	}
+}
+
+func TestWrite_MultiDoc(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatMultiDoc, []scaffold.File{
+		{Path: "a.txt", Content: "no newline"},
+		{Path: "b.txt", Content: "b\n"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	want := "--- FILE: a.txt ---\nno newline\n--- FILE: b.txt ---\nb\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWrite_UnknownFormat(t *testing.T) {
+	if err := Write(io.Discard, "rar", files); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
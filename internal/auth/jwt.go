@@ -0,0 +1,331 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	sdkauth "github.com/modelcontextprotocol/go-sdk/auth"
+)
+
+// DefaultClockSkew is the leeway applied to exp and nbf checks.
+const DefaultClockSkew = 30 * time.Second
+
+// JWTOptions configures JWT validation.
+type JWTOptions struct {
+	// Issuer, when set, must equal the token's iss claim.
+	Issuer string
+	// Audience, when set, must appear in the token's aud claim.
+	Audience string
+	// ClockSkew overrides DefaultClockSkew when non-zero.
+	ClockSkew time.Duration
+	// Now overrides time.Now, for tests.
+	Now func() time.Time
+}
+
+// JWKS is a parsed JSON Web Key Set keyed by key ID.
+type JWKS struct {
+	keys map[string]crypto.PublicKey
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// LoadJWKS reads a JSON Web Key Set from a local file.
+func LoadJWKS(path string) (*JWKS, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jwks file: %w", err)
+	}
+	return ParseJWKS(content)
+}
+
+// ParseJWKS parses a JSON Web Key Set. RSA and EC (P-256, P-384) signing keys are supported.
+func ParseJWKS(content []byte) (*JWKS, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(content, &set); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal jwks: %w", err)
+	}
+
+	ks := &JWKS{keys: make(map[string]crypto.PublicKey)}
+	for i, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("jwks key %d (%s): %w", i, k.Kid, err)
+		}
+		ks.keys[k.Kid] = key
+	}
+	if len(ks.keys) == 0 {
+		return nil, errors.New("jwks contains no signing keys")
+	}
+	return ks, nil
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus: %w", err)
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent: %w", err)
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid x coordinate: %w", err)
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid y coordinate: %w", err)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// lookup returns the key for kid. A token without kid is accepted only when the set holds a single key.
+func (ks *JWKS) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(ks.keys) == 1 {
+		for _, k := range ks.keys {
+			return k, true
+		}
+	}
+	k, ok := ks.keys[kid]
+	return k, ok
+}
+
+// Claims holds the registered JWT claims used for authorization.
+type Claims struct {
+	Subject   string   `json:"sub"`
+	Issuer    string   `json:"iss"`
+	Audience  audience `json:"aud"`
+	ExpiresAt int64    `json:"exp"`
+	NotBefore int64    `json:"nbf"`
+	Scope     string   `json:"scope"`
+	Scp       scopes   `json:"scp"`
+}
+
+// audience accepts both the string and array forms of the aud claim.
+type audience []string
+
+func (a *audience) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(b, &many); err != nil {
+		return err
+	}
+	*a = many
+	return nil
+}
+
+// scopes accepts both the space-separated string and array forms of the scp
+// claim.
+type scopes []string
+
+func (s *scopes) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*s = strings.Fields(single)
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(b, &many); err != nil {
+		return err
+	}
+	*s = many
+	return nil
+}
+
+// NewJWTVerifier returns a verifier that validates signed JWTs against keys.
+func NewJWTVerifier(keys *JWKS, opts JWTOptions) sdkauth.TokenVerifier {
+	return func(_ context.Context, token string, _ *http.Request) (*sdkauth.TokenInfo, error) {
+		claims, extra, err := verifyJWT(keys, opts, token)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", sdkauth.ErrInvalidToken, err)
+		}
+
+		scopes := []string(claims.Scp)
+		if claims.Scope != "" {
+			scopes = append(scopes, strings.Fields(claims.Scope)...)
+		}
+		return &sdkauth.TokenInfo{
+			UserID:     claims.Subject,
+			Scopes:     scopes,
+			Expiration: time.Unix(claims.ExpiresAt, 0),
+			Extra:      extra,
+		}, nil
+	}
+}
+
+func verifyJWT(keys *JWKS, opts JWTOptions, token string) (*Claims, map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, nil, errors.New("malformed token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, nil, fmt.Errorf("invalid header: %w", err)
+	}
+
+	key, ok := keys.lookup(header.Kid)
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown key id %q", header.Kid)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid signature encoding: %w", err)
+	}
+	if err := verifySignature(header.Alg, key, parts[0]+"."+parts[1], sig); err != nil {
+		return nil, nil, err
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, nil, fmt.Errorf("invalid claims: %w", err)
+	}
+	var extra map[string]any
+	if err := decodeSegment(parts[1], &extra); err != nil {
+		return nil, nil, fmt.Errorf("invalid claims: %w", err)
+	}
+
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+	skew := opts.ClockSkew
+	if skew == 0 {
+		skew = DefaultClockSkew
+	}
+	if claims.Subject == "" {
+		return nil, nil, errors.New("missing sub claim")
+	}
+	if claims.ExpiresAt == 0 {
+		return nil, nil, errors.New("missing exp claim")
+	}
+	if now().Add(-skew).After(time.Unix(claims.ExpiresAt, 0)) {
+		return nil, nil, errors.New("token expired")
+	}
+	if claims.NotBefore != 0 && now().Add(skew).Before(time.Unix(claims.NotBefore, 0)) {
+		return nil, nil, errors.New("token not yet valid")
+	}
+	if opts.Issuer != "" && claims.Issuer != opts.Issuer {
+		return nil, nil, fmt.Errorf("unexpected issuer %q", claims.Issuer)
+	}
+	if opts.Audience != "" && !slices.Contains(claims.Audience, opts.Audience) {
+		return nil, nil, errors.New("token not issued for this audience")
+	}
+	return &claims, extra, nil
+}
+
+func decodeSegment(seg string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// algCurves maps each ECDSA algorithm to the only curve it may be used with.
+var algCurves = map[string]elliptic.Curve{
+	"ES256": elliptic.P256(),
+	"ES384": elliptic.P384(),
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signed string, sig []byte) error {
+	var hash crypto.Hash
+	switch alg {
+	case "RS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported signing algorithm %q", alg)
+	}
+	h := hash.New()
+	h.Write([]byte(signed))
+	digest := h.Sum(nil)
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "RS") {
+			return fmt.Errorf("algorithm %s does not match RSA key", alg)
+		}
+		if err := rsa.VerifyPKCS1v15(k, hash, digest, sig); err != nil {
+			return errors.New("invalid signature")
+		}
+	case *ecdsa.PublicKey:
+		if curve := algCurves[alg]; curve == nil || k.Curve != curve {
+			return fmt.Errorf("algorithm %s does not match %s key", alg, k.Curve.Params().Name)
+		}
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return errors.New("invalid signature")
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(k, digest, r, s) {
+			return errors.New("invalid signature")
+		}
+	default:
+		return errors.New("unsupported key")
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	sdkauth "github.com/modelcontextprotocol/go-sdk/auth"
+)
+
+func b64(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+func signJWT(t *testing.T, alg, kid string, key crypto.Signer, claims map[string]any) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := b64(header) + "." + b64(payload)
+	digest := sha256.Sum256([]byte(signed))
+
+	var sig []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		s, err := rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig = s
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		size := (k.Curve.Params().BitSize + 7) / 8
+		sig = append(r.FillBytes(make([]byte, size)), s.FillBytes(make([]byte, size))...)
+	}
+	return signed + "." + b64(sig)
+}
+
+func testJWKS(t *testing.T, rsaKey *rsa.PrivateKey, ecKeys ...*ecdsa.PrivateKey) *JWKS {
+	t.Helper()
+	keys := []map[string]string{
+		{"kty": "RSA", "kid": "rsa-1", "use": "sig", "n": b64(rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(rsaKey.E)).Bytes())},
+	}
+	for i, k := range ecKeys {
+		size := (k.Curve.Params().BitSize + 7) / 8
+		keys = append(keys, map[string]string{
+			"kty": "EC", "kid": fmt.Sprintf("ec-%d", i+1), "crv": k.Curve.Params().Name,
+			"x": b64(k.X.FillBytes(make([]byte, size))), "y": b64(k.Y.FillBytes(make([]byte, size))),
+		})
+	}
+	set := map[string]any{"keys": keys}
+	content, _ := json.Marshal(set)
+	ks, err := ParseJWKS(content)
+	if err != nil {
+		t.Fatalf("ParseJWKS failed: %v", err)
+	}
+	return ks
+}
+
+func TestJWTVerifier(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Unix(1_700_000_000, 0)
+	verifier := NewJWTVerifier(testJWKS(t, rsaKey, ecKey, p384Key), JWTOptions{
+		Issuer:   "https://idp.example.com",
+		Audience: "platform-mcp",
+		Now:      func() time.Time { return now },
+	})
+
+	valid := func() map[string]any {
+		return map[string]any{
+			"sub":   "alice",
+			"iss":   "https://idp.example.com",
+			"aud":   []string{"platform-mcp"},
+			"exp":   now.Add(time.Hour).Unix(),
+			"scope": "scaffold:read scaffold:write",
+		}
+	}
+	with := func(k string, v any) map[string]any {
+		c := valid()
+		if v == nil {
+			delete(c, k)
+		} else {
+			c[k] = v
+		}
+		return c
+	}
+	withScp := func(v any) map[string]any {
+		c := with("scope", nil)
+		c["scp"] = v
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"RS256", signJWT(t, "RS256", "rsa-1", rsaKey, valid()), false},
+		{"ES256", signJWT(t, "ES256", "ec-1", ecKey, valid()), false},
+		{"String Audience", signJWT(t, "RS256", "rsa-1", rsaKey, with("aud", "platform-mcp")), false},
+		{"String Scp", signJWT(t, "RS256", "rsa-1", rsaKey, withScp("scaffold:read scaffold:write")), false},
+		{"Array Scp", signJWT(t, "RS256", "rsa-1", rsaKey, withScp([]string{"scaffold:read", "scaffold:write"})), false},
+		{"Wrong Key", signJWT(t, "RS256", "rsa-1", otherKey, valid()), true},
+		{"Unknown Kid", signJWT(t, "RS256", "rsa-2", rsaKey, valid()), true},
+		{"Alg Mismatch", signJWT(t, "ES256", "rsa-1", rsaKey, valid()), true},
+		{"Curve Mismatch", signJWT(t, "ES256", "ec-2", p384Key, valid()), true},
+		{"Expired", signJWT(t, "RS256", "rsa-1", rsaKey, with("exp", now.Add(-time.Hour).Unix())), true},
+		{"Not Yet Valid", signJWT(t, "RS256", "rsa-1", rsaKey, with("nbf", now.Add(time.Hour).Unix())), true},
+		{"Wrong Issuer", signJWT(t, "RS256", "rsa-1", rsaKey, with("iss", "https://evil.example.com")), true},
+		{"Wrong Audience", signJWT(t, "RS256", "rsa-1", rsaKey, with("aud", "other")), true},
+		{"Missing Subject", signJWT(t, "RS256", "rsa-1", rsaKey, with("sub", nil)), true},
+		{"Malformed", "not-a-jwt", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := verifier(context.Background(), tt.token, nil)
+			if tt.wantErr {
+				if !errors.Is(err, sdkauth.ErrInvalidToken) {
+					t.Errorf("expected ErrInvalidToken, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if info.UserID != "alice" {
+				t.Errorf("UserID = %q, want alice", info.UserID)
+			}
+			if len(info.Scopes) != 2 {
+				t.Errorf("Scopes = %v, want 2 entries", info.Scopes)
+			}
+			if !info.Expiration.Equal(now.Add(time.Hour)) {
+				t.Errorf("Expiration = %v", info.Expiration)
+			}
+		})
+	}
+}
+
+func TestParseJWKS_Invalid(t *testing.T) {
+	cases := map[string]string{
+		"Not JSON":     `nope`,
+		"No Keys":      `{"keys":[]}`,
+		"Bad Kty":      `{"keys":[{"kty":"oct","kid":"x"}]}`,
+		"Bad Curve":    `{"keys":[{"kty":"EC","kid":"x","crv":"P-521","x":"AA","y":"AA"}]}`,
+		"Only Enc Key": `{"keys":[{"kty":"RSA","kid":"x","use":"enc","n":"AQAB","e":"AQAB"}]}`,
+	}
+	for name, content := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseJWKS([]byte(content)); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
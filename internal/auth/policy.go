@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Wildcard grants access to every tool or template.
+const Wildcard = "*"
+
+// Grant lists the tools and templates an identity may use. Entries are
+// path.Match patterns, so "*-workflow" matches every workflow template.
+type Grant struct {
+	Tools     []string `yaml:"tools"`
+	Templates []string `yaml:"templates"`
+}
+
+// Policy maps identities (token subjects) to grants.
+type Policy struct {
+	// Default applies to authenticated identities without an explicit entry.
+	Default    Grant            `yaml:"default"`
+	Identities map[string]Grant `yaml:"identities"`
+}
+
+// LoadPolicy reads and validates a policy file.
+func LoadPolicy(filePath string) (*Policy, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+
+	var p Policy
+	if err := yaml.Unmarshal(content, &p); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal policy: %w", err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// AllowAll returns a policy granting every identity access to everything.
+func AllowAll() *Policy {
+	return &Policy{Default: Grant{Tools: []string{Wildcard}, Templates: []string{Wildcard}}}
+}
+
+// Validate checks that every pattern in the policy is well formed.
+func (p *Policy) Validate() error {
+	check := func(who string, g Grant) error {
+		for _, pat := range append(append([]string{}, g.Tools...), g.Templates...) {
+			if _, err := path.Match(pat, ""); err != nil {
+				return fmt.Errorf("policy for %s: invalid pattern %q: %w", who, pat, err)
+			}
+		}
+		return nil
+	}
+	if err := check("default", p.Default); err != nil {
+		return err
+	}
+	for id, g := range p.Identities {
+		if err := check(id, g); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GrantFor returns the grant for identity, falling back to the default grant.
+func (p *Policy) GrantFor(identity string) Grant {
+	if g, ok := p.Identities[identity]; ok {
+		return g
+	}
+	return p.Default
+}
+
+// AllowsTool reports whether the grant permits calling the named tool.
+func (g Grant) AllowsTool(name string) bool {
+	return matchAny(g.Tools, name)
+}
+
+// AllowsTemplate reports whether the grant permits rendering the named template mapping.
+func (g Grant) AllowsTemplate(name string) bool {
+	return matchAny(g.Templates, name)
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pat := range patterns {
+		if ok, _ := path.Match(pat, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+type grantKey struct{}
+
+// Principal is an authenticated caller and what it may do.
+type Principal struct {
+	Identity string
+	Grant    Grant
+}
+
+// WithPrincipal returns a copy of ctx carrying p.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, grantKey{}, p)
+}
+
+// PrincipalFromContext returns the principal stored in ctx, if any. Requests
+// over unauthenticated transports such as stdio carry no principal.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(grantKey{}).(Principal)
+	return p, ok
+}
@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPolicy_GrantFor(t *testing.T) {
+	p := &Policy{
+		Default: Grant{Tools: []string{"generate_workflows"}, Templates: []string{"*-workflow", "actions-workflow"}},
+		Identities: map[string]Grant{
+			"alice": {Tools: []string{Wildcard}, Templates: []string{Wildcard}},
+		},
+	}
+
+	alice := p.GrantFor("alice")
+	if !alice.AllowsTool("generate") || !alice.AllowsTemplate("flux-manifest") {
+		t.Error("alice should be allowed everything")
+	}
+
+	bob := p.GrantFor("bob")
+	if bob.AllowsTool("generate") {
+		t.Error("bob should not be allowed to call generate")
+	}
+	if !bob.AllowsTool("generate_workflows") {
+		t.Error("bob should be allowed to call generate_workflows")
+	}
+	if !bob.AllowsTemplate("go-workflow") || bob.AllowsTemplate("dockerfile") {
+		t.Error("bob template grant does not match the default patterns")
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.yaml")
+	content := "default:\n  tools: [generate_workflows]\nidentities:\n  alice:\n    tools: ['*']\n    templates: ['*']\n"
+	if err := os.WriteFile(valid, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	p, err := LoadPolicy(valid)
+	if err != nil {
+		t.Fatalf("LoadPolicy failed: %v", err)
+	}
+	if !p.GrantFor("alice").AllowsTool("generate") {
+		t.Error("expected alice to be allowed generate")
+	}
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalid, []byte("default:\n  tools: ['[']\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadPolicy(invalid); err == nil {
+		t.Error("expected error for malformed pattern")
+	}
+}
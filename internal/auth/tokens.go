@@ -0,0 +1,78 @@
+// Package auth provides bearer token verification and identity-based
+// authorization for the platform-mcp HTTP transport.
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	sdkauth "github.com/modelcontextprotocol/go-sdk/auth"
+	"gopkg.in/yaml.v3"
+)
+
+// staticTokenLifetime is the expiration reported for static tokens, which never expire on their own.
+const staticTokenLifetime = time.Hour
+
+// StaticToken maps an opaque bearer token to an identity.
+type StaticToken struct {
+	Subject string   `yaml:"subject"`
+	Token   string   `yaml:"token"`
+	Scopes  []string `yaml:"scopes"`
+}
+
+// StaticTokenFile is the on-disk format of a static token file.
+type StaticTokenFile struct {
+	Tokens []StaticToken `yaml:"tokens"`
+}
+
+// LoadStaticTokens reads and validates a static token file.
+func LoadStaticTokens(path string) ([]StaticToken, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	var f StaticTokenFile
+	if err := yaml.Unmarshal(content, &f); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token file: %w", err)
+	}
+
+	seen := make(map[string]bool, len(f.Tokens))
+	for i, t := range f.Tokens {
+		if t.Subject == "" {
+			return nil, fmt.Errorf("token %d: subject is required", i)
+		}
+		if t.Token == "" {
+			return nil, fmt.Errorf("token %d (%s): token is required", i, t.Subject)
+		}
+		if seen[t.Token] {
+			return nil, fmt.Errorf("token %d (%s): duplicate token", i, t.Subject)
+		}
+		seen[t.Token] = true
+	}
+	if len(f.Tokens) == 0 {
+		return nil, errors.New("token file contains no tokens")
+	}
+	return f.Tokens, nil
+}
+
+// NewStaticTokenVerifier returns a verifier that accepts exactly the given tokens.
+func NewStaticTokenVerifier(tokens []StaticToken) sdkauth.TokenVerifier {
+	return func(_ context.Context, token string, _ *http.Request) (*sdkauth.TokenInfo, error) {
+		for _, t := range tokens {
+			if subtle.ConstantTimeCompare([]byte(t.Token), []byte(token)) == 1 {
+				return &sdkauth.TokenInfo{
+					UserID:     t.Subject,
+					Scopes:     t.Scopes,
+					Expiration: time.Now().Add(staticTokenLifetime),
+				}, nil
+			}
+		}
+		return nil, fmt.Errorf("%w: unknown token", sdkauth.ErrInvalidToken)
+	}
+}
@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	sdkauth "github.com/modelcontextprotocol/go-sdk/auth"
+)
+
+func TestLoadStaticTokens(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{"Valid", "tokens:\n  - subject: alice\n    token: secret-a\n  - subject: ci\n    token: secret-b\n", false},
+		{"Empty", "tokens: []\n", true},
+		{"Missing Subject", "tokens:\n  - token: secret-a\n", true},
+		{"Missing Token", "tokens:\n  - subject: alice\n", true},
+		{"Duplicate Token", "tokens:\n  - subject: a\n    token: x\n  - subject: b\n    token: x\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "tokens.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := LoadStaticTokens(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadStaticTokens() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStaticTokenVerifier(t *testing.T) {
+	verifier := NewStaticTokenVerifier([]StaticToken{{Subject: "alice", Token: "secret-a"}})
+
+	info, err := verifier(context.Background(), "secret-a", nil)
+	if err != nil {
+		t.Fatalf("expected token to verify, got %v", err)
+	}
+	if info.UserID != "alice" || info.Expiration.IsZero() {
+		t.Errorf("unexpected token info: %+v", info)
+	}
+
+	if _, err := verifier(context.Background(), "secret-b", nil); !errors.Is(err, sdkauth.ErrInvalidToken) {
+		t.Errorf("expected ErrInvalidToken, got %v", err)
+	}
+}
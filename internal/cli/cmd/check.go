@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	stdio "io"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/modelcontextprotocol/platform.mcp/internal/cli/io"
+	"github.com/modelcontextprotocol/platform.mcp/internal/project"
+	"github.com/spf13/cobra"
+)
+
+var checkFormat string
+
+var checkCmd = &cobra.Command{
+	Use:   "check [directory]",
+	Short: "Fail if generated files drifted from what the templates produce",
+	Long: `Regenerate, in memory, the scaffold recorded in ` + project.ConfigFile + ` by the
+last generate run and compare it with the files on disk. Files edited by hand
+are reported as modified; files that still match ` + project.LockFile + ` but
+that the current templates would generate differently are reported as
+outdated. The command exits non-zero if any file drifted.
+
+--format selects text (with unified diffs), json, yaml or sarif, for code
+scanning in CI. It defaults to the global --output format.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := "."
+		if len(args) == 1 {
+			dir = args[0]
+		}
+		out := cmd.OutOrStdout()
+		format := checkFormat
+		if format == "" {
+			format = outputFormat
+		}
+		if !slices.Contains([]string{"text", "json", "yaml", "sarif"}, format) {
+			return fmt.Errorf("unsupported format %q (text, json, yaml, sarif)", format)
+		}
+
+		drift, err := project.Check(cmd.Context(), dir)
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("no %s in %s: run platform generate first", project.ConfigFile, dir)
+		}
+		if err != nil {
+			return err
+		}
+
+		switch format {
+		case "text":
+			for _, d := range drift {
+				fmt.Fprintf(out, "✘ %s: %s\n%s", d.Path, d.Status, d.Diff)
+			}
+			if len(drift) == 0 {
+				fmt.Fprintln(out, "✔ Generated files are up to date")
+			}
+		case "json", "yaml":
+			if drift == nil {
+				drift = []project.Drift{}
+			}
+			if err := io.Encode(out, format, struct {
+				Files []project.Drift `json:"files"`
+			}{drift}); err != nil {
+				return err
+			}
+		case "sarif":
+			if err := writeSARIF(out, drift); err != nil {
+				return err
+			}
+		}
+
+		if len(drift) > 0 {
+			return fmt.Errorf("%d generated files drifted", len(drift))
+		}
+		return nil
+	},
+}
+
+// checkRules describes each drift status as a SARIF rule.
+var checkRules = []struct{ status, description string }{
+	{project.DriftMissing, "A generated file is missing."},
+	{project.DriftModified, "A generated file was edited by hand."},
+	{project.DriftOutdated, "A generated file is out of date with the templates."},
+}
+
+var hunkStart = regexp.MustCompile(`(?m)^@@ -(\d+)`)
+
+// writeSARIF writes drift as a SARIF 2.1.0 log, one result per file.
+func writeSARIF(w stdio.Writer, drift []project.Drift) error {
+	type message struct {
+		Text string `json:"text"`
+	}
+	type rule struct {
+		ID               string  `json:"id"`
+		ShortDescription message `json:"shortDescription"`
+	}
+	type region struct {
+		StartLine int `json:"startLine"`
+	}
+	type location struct {
+		PhysicalLocation struct {
+			ArtifactLocation struct {
+				URI string `json:"uri"`
+			} `json:"artifactLocation"`
+			Region *region `json:"region,omitempty"`
+		} `json:"physicalLocation"`
+	}
+	type result struct {
+		RuleID    string     `json:"ruleId"`
+		Level     string     `json:"level"`
+		Message   message    `json:"message"`
+		Locations []location `json:"locations"`
+	}
+
+	rules := make([]rule, 0, len(checkRules))
+	for _, r := range checkRules {
+		rules = append(rules, rule{ID: "platform/" + r.status, ShortDescription: message{r.description}})
+	}
+	results := make([]result, 0, len(drift))
+	for _, d := range drift {
+		var loc location
+		loc.PhysicalLocation.ArtifactLocation.URI = d.Path
+		// Point at the first hunk of a file that exists.
+		if m := hunkStart.FindStringSubmatch(d.Diff); m != nil && d.Status != project.DriftMissing {
+			line, _ := strconv.Atoi(m[1])
+			loc.PhysicalLocation.Region = &region{StartLine: max(line, 1)}
+		}
+		results = append(results, result{
+			RuleID:    "platform/" + d.Status,
+			Level:     "error",
+			Message:   message{fmt.Sprintf("%s is %s; run platform generate (or platform upgrade to keep local edits) to update it.", d.Path, d.Status)},
+			Locations: []location{loc},
+		})
+	}
+
+	log := map[string]any{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": []any{map[string]any{
+			"tool": map[string]any{
+				"driver": map[string]any{"name": "platform", "rules": rules},
+			},
+			"results": results,
+		}},
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+	checkCmd.Flags().StringVar(&checkFormat, "format", "", "Report format: text, json, yaml or sarif; defaults to the --output format")
+}
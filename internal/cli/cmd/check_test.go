@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/platform.mcp/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+func TestCheckCommand(t *testing.T) {
+	resetGenerateFlags()
+	defer resetGenerateFlags()
+	defer func() { checkFormat = "" }()
+	dir := t.TempDir()
+
+	run := func(args ...string) (string, error) {
+		t.Helper()
+		checkFormat = ""
+		root := &cobra.Command{Use: "platform"}
+		root.AddCommand(generateCmd, checkCmd)
+		buf := new(bytes.Buffer)
+		root.SetOut(buf)
+		root.SetErr(new(bytes.Buffer))
+		root.SetArgs(args)
+		err := root.Execute()
+		return buf.String(), err
+	}
+
+	if _, err := run("generate", "--project-name", "api", "--with-actions", "--dir", dir); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := run("check", dir); err != nil || !strings.Contains(out, "up to date") {
+		t.Fatalf("check after generate = %v\n%s", err, out)
+	}
+
+	// Edit ci.yaml by hand and change the template behind go.yaml.
+	if err := os.WriteFile(filepath.Join(dir, ".github", "workflows", "ci.yaml"), []byte("edited\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	layer := t.TempDir()
+	if err := os.WriteFile(filepath.Join(layer, "go.yaml.tmpl"), []byte("name: {{ .ProjectName }}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := templates.SetLayers([]string{layer}); err != nil {
+		t.Fatal(err)
+	}
+	defer templates.Activate(nil)
+
+	out, err := run("check", dir)
+	if err == nil {
+		t.Fatal("check succeeded despite drift")
+	}
+	for _, want := range []string{"✘ .github/workflows/ci.yaml: modified", "-edited", "✘ .github/workflows/go.yaml: outdated", "+name: api"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("text report lacks %q:\n%s", want, out)
+		}
+	}
+
+	out, _ = run("check", "--format", "json", dir)
+	var report struct {
+		Files []struct{ Path, Status string }
+	}
+	if err := json.Unmarshal([]byte(out), &report); err != nil || len(report.Files) != 2 {
+		t.Errorf("json report = %v\n%s", err, out)
+	}
+
+	out, _ = run("check", "--format", "sarif", dir)
+	var sarif struct {
+		Version string
+		Runs    []struct {
+			Results []struct {
+				RuleID string
+			}
+		}
+	}
+	if err := json.Unmarshal([]byte(out), &sarif); err != nil || sarif.Version != "2.1.0" || len(sarif.Runs[0].Results) != 2 {
+		t.Fatalf("sarif report = %v\n%s", err, out)
+	}
+	if got := sarif.Runs[0].Results[1].RuleID; got != "platform/outdated" {
+		t.Errorf("second result rule = %q, want platform/outdated", got)
+	}
+}
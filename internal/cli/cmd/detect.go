@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/platform.mcp/internal/cli/io"
+	"github.com/modelcontextprotocol/platform.mcp/internal/detect"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+	"github.com/spf13/cobra"
+)
+
+var detectCmd = &cobra.Command{
+	Use:   "detect [directory]",
+	Short: "Suggest generate options for an existing repository",
+	Long: `Inspect a directory (go.mod, package.json, tsconfig.json, pyproject.toml,
+requirements.txt, Dockerfile, .github/workflows, deploy/) and suggest the
+generate options that match it, with a confidence note for each.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := "."
+		if len(args) == 1 {
+			dir = args[0]
+		}
+
+		report, err := detect.Analyze(dir)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		if outputFormat != io.FormatText {
+			return io.Encode(out, outputFormat, report)
+		}
+
+		if err := report.WriteText(out); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "\nRun:\n  %s\n", generateCommandLine(report.Config))
+		return nil
+	},
+}
+
+// generateCommandLine returns the platform generate invocation for cfg.
+func generateCommandLine(cfg scaffold.Config) string {
+	args := []string{"platform", "generate", "--project-name", cfg.ProjectName, "--workflow-type", cfg.WorkflowType}
+	if cfg.WithActions {
+		args = append(args, "--with-actions")
+	}
+	if cfg.WithDocker {
+		args = append(args, "--with-docker")
+	}
+	if cfg.WithFlux {
+		args = append(args, "--with-flux")
+	}
+	return strings.Join(args, " ")
+}
+
+func init() {
+	rootCmd.AddCommand(detectCmd)
+}
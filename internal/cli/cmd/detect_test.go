@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestDetectCommand(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"name": "storefront"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "tsconfig.json"), []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) string {
+		t.Helper()
+		defer func() { outputFormat = "text" }()
+		var out bytes.Buffer
+		root := &cobra.Command{Use: "platform"}
+		addOutputFlag(root)
+		root.AddCommand(detectCmd)
+		root.SetOut(&out)
+		root.SetArgs(append([]string{"detect"}, args...))
+		if err := root.Execute(); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		return out.String()
+	}
+
+	text := run(dir)
+	want := "platform generate --project-name storefront --workflow-type typescript --with-actions"
+	if !strings.Contains(text, want) {
+		t.Errorf("output missing %q:\n%s", want, text)
+	}
+	if !strings.Contains(text, "tsconfig.json found") {
+		t.Errorf("output missing confidence notes:\n%s", text)
+	}
+
+	var report struct {
+		Config map[string]any `json:"config"`
+	}
+	if err := json.Unmarshal([]byte(run("--output", "json", dir)), &report); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if report.Config["project_name"] != "storefront" {
+		t.Errorf("unexpected config %v", report.Config)
+	}
+}
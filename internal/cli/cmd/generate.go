@@ -1,13 +1,26 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
 	"fmt"
+	stdio "io"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
+	"github.com/modelcontextprotocol/platform.mcp/internal/archive"
 	"github.com/modelcontextprotocol/platform.mcp/internal/cli/io"
+	"github.com/modelcontextprotocol/platform.mcp/internal/project"
+	"github.com/modelcontextprotocol/platform.mcp/internal/workspace"
 	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 var (
@@ -17,73 +30,563 @@ var (
 	withActions  bool
 	withFlux     bool
 	workflowType string
+	org          string
+	registry     string
+	branch       string
+	environments []string
+	variables    map[string]string
 	dryRun       bool
+	showDiff     bool
 	force        bool
 	outputDir    string
+	batchFile    string
+	format       string
+	archiveFile  string
+	onConflict   string
+	gitBranch    string
+	gitCommit    bool
+	gitRecord    bool
+)
+
+// Values of generate --format besides the archive formats.
+const (
+	formatDir            = "dir"
+	formatStdoutMultiDoc = "stdout-multidoc"
 )
 
 var generateCmd = &cobra.Command{
 	Use:   "generate",
 	Short: "Generate project scaffolds",
+	Long: `Generate project scaffolds. The options are read from the project file
+(.platform.yaml) in the target directory if there is one; flags given on the
+command line override it.
+
+Existing files whose content differs are handled as --on-conflict says:
+skipped, overwritten, prompted for, refused, or merged with the generated
+changes against the copy stored under .platform/base, marking overlapping
+edits with conflict markers. Files already up to date are left alone.
+
+Writes are all or nothing: every file is staged next to its target and
+renamed into place only once all of them are ready. If one fails, the files
+already replaced are restored and the others are reported as rolled back.
+
+With --output json or yaml, the result is printed as a report listing every
+file with the action taken (created, skipped, overwritten, unchanged, merged,
+failed, rolled-back or archived), its path and the sha256 of the generated
+content, plus any error. Existing files are then skipped rather than
+prompted for, unless --force or --on-conflict is set.
+
+With --git-branch, the working tree of the git repository containing the
+directory must be clean, apart from the record under .platform that every
+run rewrites. Once the files are written, the branch is created from HEAD
+and exactly the generated files are staged on it; if the write fails, no
+branch is created. --git-record also stages the record: .platform/config.json,
+the lockfile and the stored bases. --commit then commits the staged files
+with a conventional-commit message listing the generated components.
+
+With --format tar or zip, the files are packed into an archive, keeping their
+modes, instead of being written into the directory. The archive goes to
+--archive-file, or to stdout so that it can be piped into tar -x.
+--format stdout-multidoc streams the files to stdout as text, each preceded
+by a "--- FILE: <path> ---" line.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg := scaffold.Config{
-			ProjectName:  projectName,
-			UseDocker:    withDocker || useDocker, // Support both for now
-			WithDocker:   withDocker,
-			WithActions:  withActions,
-			WithFlux:     withFlux,
-			WorkflowType: workflowType,
+		out := cmd.OutOrStdout()
+		report := generateReport{Directory: outputDir, DryRun: dryRun || showDiff}
+		// Machine-readable output always ends with the report, failed or not.
+		fail := func(err error) error {
+			if outputFormat != io.FormatText {
+				report.Error = err.Error()
+				if encErr := io.Encode(out, outputFormat, report); encErr != nil {
+					return encErr
+				}
+			}
+			return err
 		}
 
-		if cfg.ProjectName == "" {
-			dir, _ := os.Getwd()
-			cfg.ProjectName = filepath.Base(dir)
+		archiveFormat, err := generateArchiveFormat()
+		if err != nil {
+			return err
+		}
+		opts, err := generateOptions()
+		if err != nil {
+			return err
+		}
+		repo, err := generateGit()
+		if err != nil {
+			return err
 		}
 
-		// Use ProjectGenerator for multi-component scaffolding
-		gen := scaffold.NewProjectGenerator()
-		files, err := gen.Generate(cfg)
+		var files []scaffold.File
+		var generation project.Generation
+		if batchFile != "" {
+			files, generation, err = generateBatch(cmd.Context(), batchFile)
+		} else {
+			files, generation, err = generateProject(cmd)
+		}
 		if err != nil {
-			return fmt.Errorf("failed to generate scaffold: %w", err)
+			return fail(err)
+		}
+		if archiveFormat != "" {
+			return generateArchive(cmd, archiveFormat, files, report)
 		}
 
-		for _, file := range files {
-			targetPath := filepath.Join(outputDir, file.Path)
-			if dryRun {
-				fmt.Printf("[DRY RUN] Would create %s\n", targetPath)
-				continue
+		// --diff and --dry-run report what would be written, writing nothing.
+		preview := showDiff || dryRun
+		var sink workspace.Writer = workspace.Dir(outputDir)
+		if preview {
+			sink = workspace.DryRun{Base: workspace.Dir(outputDir)}
+			if opts.Policy == workspace.PolicyPrompt {
+				opts.Prompt = func(string) (bool, error) { return true, nil }
 			}
-
-			if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
-				return fmt.Errorf("failed to create directory: %w", err)
+		}
+		if repo != nil {
+			sink = repo
+		}
+		results, err := workspace.Write(sink, files, opts)
+		for i, r := range results {
+			report.Files = append(report.Files, fileReport{Path: r.Path, Action: r.Action, SHA256: sha256Hex(files[i].Content), Conflicts: r.Conflicts, Error: r.Error})
+		}
+		if err != nil {
+			if outputFormat == io.FormatText {
+				printResults(out, outputDir, results)
 			}
+			return fail(err)
+		}
 
-			if _, err := os.Stat(targetPath); err == nil && !force {
-				if !isTerminal() {
-					return fmt.Errorf("file %s already exists, use --force to overwrite (non-interactive mode)", targetPath)
+		if preview {
+			if showDiff {
+				diffs, err := workspace.Compare(outputDir, files)
+				if err != nil {
+					return fail(fmt.Errorf("failed to compare scaffold: %w", err))
 				}
-				if !io.Confirm(fmt.Sprintf("? File %s already exists. Overwrite?", targetPath)) {
-					fmt.Printf("Skipped %s\n", targetPath)
-					continue
+				if outputFormat == io.FormatText {
+					for _, d := range diffs {
+						fmt.Fprintf(out, "%s %s\n", d.Status, filepath.Join(outputDir, d.Path))
+						fmt.Fprint(out, d.Diff)
+					}
+					return nil
 				}
+				for i, d := range diffs {
+					report.Files[i].Diff = d.Diff
+				}
+			}
+			if outputFormat != io.FormatText {
+				return io.Encode(out, outputFormat, report)
+			}
+			for _, r := range results {
+				fmt.Fprintf(out, "[DRY RUN] Would %s %s\n", dryRunVerbs[r.Action], filepath.Join(outputDir, r.Path))
 			}
+			fmt.Fprintln(out, "Dry run complete. No files were written.")
+			return nil
+		}
 
-			if err := os.WriteFile(targetPath, []byte(file.Content), os.FileMode(file.Mode)); err != nil {
-				return fmt.Errorf("failed to write file: %w", err)
+		if err := project.Record(outputDir, generation, files, results); err != nil {
+			return fail(err)
+		}
+		conflicts := 0
+		for _, r := range results {
+			if r.Conflicts > 0 {
+				conflicts++
+			}
+		}
+		if conflicts > 0 {
+			err = fmt.Errorf("%d files have conflicts: resolve the <<<<<<< markers and commit", conflicts)
+		}
+		if repo != nil {
+			// The branch only exists once the files are in place.
+			if err := repo.CreateBranch(gitBranch); err != nil {
+				return fail(err)
+			}
+			report.Branch = gitBranch
+			if err := repo.Close(); err != nil {
+				return fail(err)
+			}
+			if gitRecord {
+				if err := repo.Add(gitRecordPaths...); err != nil {
+					return fail(err)
+				}
+			}
+			// Conflict markers are left staged for review, not committed.
+			if gitCommit && err == nil {
+				if report.Commit, err = repo.Commit(commitMessage(generation)); err != nil {
+					return fail(err)
+				}
 			}
-			fmt.Printf("✔ Created %s\n", targetPath)
 		}
 
-		if dryRun {
-			fmt.Println("Dry run complete. No files were written.")
-		} else {
-			fmt.Printf("Generation complete! %d files created.\n", len(files))
+		if outputFormat != io.FormatText {
+			if err != nil {
+				return fail(err)
+			}
+			return io.Encode(out, outputFormat, report)
 		}
+		written := printResults(out, outputDir, results)
+		fmt.Fprintf(out, "✔ Recorded %s\n", filepath.Join(outputDir, project.LockFile))
+		switch {
+		case report.Commit != "":
+			fmt.Fprintf(out, "✔ Committed %s on branch %s\n", report.Commit[:min(len(report.Commit), 12)], gitBranch)
+		case repo != nil:
+			fmt.Fprintf(out, "✔ Staged the generated files on branch %s\n", gitBranch)
+		}
+		fmt.Fprintf(out, "Generation complete! %d files written.\n", written)
 
-		return nil
+		return err
 	},
 }
 
+// dryRunVerbs describes each write action in dry-run output.
+var dryRunVerbs = map[workspace.Action]string{
+	workspace.ActionCreated:     "create",
+	workspace.ActionOverwritten: "overwrite",
+	workspace.ActionSkipped:     "skip",
+	workspace.ActionUnchanged:   "leave unchanged",
+	workspace.ActionMerged:      "merge",
+}
+
+// actionArchived is reported by generate for files packed into an archive.
+const actionArchived workspace.Action = "archived"
+
+// generateReport is what generate prints with --output json or yaml.
+type generateReport struct {
+	Directory string       `json:"directory"`
+	Archive   string       `json:"archive,omitempty"`
+	Branch    string       `json:"branch,omitempty"`
+	Commit    string       `json:"commit,omitempty"`
+	DryRun    bool         `json:"dry_run,omitempty"`
+	Files     []fileReport `json:"files"`
+	Error     string       `json:"error,omitempty"`
+}
+
+// fileReport is one generated file in a generateReport. SHA256 is the digest
+// of the generated content; Diff is set with --diff.
+type fileReport struct {
+	Path      string           `json:"path"`
+	Action    workspace.Action `json:"action"`
+	SHA256    string           `json:"sha256"`
+	Conflicts int              `json:"conflicts,omitempty"`
+	Diff      string           `json:"diff,omitempty"`
+	Error     string           `json:"error,omitempty"`
+}
+
+// printResults prints what Write did with each file below dir and returns
+// how many files were written: created, overwritten or merged.
+func printResults(out stdio.Writer, dir string, results []workspace.Result) int {
+	written := 0
+	for _, r := range results {
+		path := filepath.Join(dir, r.Path)
+		switch r.Action {
+		case workspace.ActionSkipped:
+			fmt.Fprintf(out, "Skipped %s\n", path)
+		case workspace.ActionUnchanged:
+			fmt.Fprintf(out, "Unchanged %s\n", path)
+		case workspace.ActionRolledBack:
+			fmt.Fprintf(out, "Rolled back %s\n", path)
+		case workspace.ActionFailed:
+			fmt.Fprintf(out, "✘ Failed %s: %s\n", path, r.Error)
+		case workspace.ActionMerged:
+			written++
+			if r.Conflicts > 0 {
+				fmt.Fprintf(out, "✘ Merged %s with %d conflicts\n", path, r.Conflicts)
+			} else {
+				fmt.Fprintf(out, "✔ Merged %s\n", path)
+			}
+		case workspace.ActionCreated:
+			written++
+			fmt.Fprintf(out, "✔ Created %s\n", path)
+		case workspace.ActionOverwritten:
+			written++
+			fmt.Fprintf(out, "✔ Overwrote %s\n", path)
+		default:
+			fmt.Fprintf(out, "%s %s\n", r.Action, path)
+		}
+	}
+	return written
+}
+
+// generateOptions returns the write options selected by --on-conflict and
+// --force. Without either, existing files are prompted for, or skipped with
+// machine-readable output, which prompts would corrupt.
+func generateOptions() (workspace.Options, error) {
+	opts := workspace.Options{
+		Policy: workspace.Policy(onConflict),
+		Prompt: func(path string) (bool, error) { return confirmOverwrite(filepath.Join(outputDir, path)) },
+		Base:   workspace.Dir(filepath.Join(outputDir, project.BaseDir)),
+	}
+	if opts.Policy != "" && !slices.Contains(workspace.Policies, opts.Policy) {
+		return opts, fmt.Errorf("unsupported conflict policy %q (skip, overwrite, prompt, fail, merge)", onConflict)
+	}
+	if force {
+		if opts.Policy != "" && opts.Policy != workspace.PolicyOverwrite {
+			return opts, fmt.Errorf("--force cannot be combined with --on-conflict %s", opts.Policy)
+		}
+		opts.Policy = workspace.PolicyOverwrite
+	}
+	switch {
+	case opts.Policy == "" && outputFormat == io.FormatText:
+		opts.Policy = workspace.PolicyPrompt
+	case opts.Policy == "":
+		opts.Policy = workspace.PolicySkip
+	case opts.Policy == workspace.PolicyPrompt && outputFormat != io.FormatText:
+		return opts, fmt.Errorf("--on-conflict prompt cannot be combined with --output %s", outputFormat)
+	}
+	return opts, nil
+}
+
+// generateArchiveFormat checks --format and the flags it cannot be combined
+// with, and returns the archive format to pack the files in, or "" to write
+// them into the directory.
+func generateArchiveFormat() (string, error) {
+	var archiveFormat string
+	switch format {
+	case formatDir:
+		if archiveFile != "" {
+			return "", errors.New("--archive-file requires --format tar or zip")
+		}
+		return "", nil
+	case archive.FormatTar, archive.FormatZip:
+		archiveFormat = format
+	case formatStdoutMultiDoc:
+		if archiveFile != "" {
+			return "", fmt.Errorf("--format %s always writes to stdout; use --archive-file with tar or zip", format)
+		}
+		archiveFormat = archive.FormatMultiDoc
+	default:
+		return "", fmt.Errorf("unsupported format %q (%s, %s, %s, %s)", format, formatDir, archive.FormatTar, archive.FormatZip, formatStdoutMultiDoc)
+	}
+	if dryRun || showDiff {
+		return "", fmt.Errorf("--format %s cannot be combined with --dry-run or --diff", format)
+	}
+	if archiveFile == "" && outputFormat != io.FormatText {
+		return "", fmt.Errorf("--output %s cannot be combined with --format %s on stdout; use --archive-file", outputFormat, format)
+	}
+	return archiveFormat, nil
+}
+
+// generateArchive packs files into an archive written to --archive-file or
+// stdout. The output directory is left untouched, so nothing is recorded.
+func generateArchive(cmd *cobra.Command, archiveFormat string, files []scaffold.File, report generateReport) error {
+	out := cmd.OutOrStdout()
+	if archiveFile == "" {
+		return writeArchive(out, archiveFormat, files)
+	}
+
+	var buf bytes.Buffer
+	if err := writeArchive(&buf, archiveFormat, files); err != nil {
+		return err
+	}
+	if err := os.WriteFile(archiveFile, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	if outputFormat != io.FormatText {
+		report.Archive = archiveFile
+		for _, f := range files {
+			report.Files = append(report.Files, fileReport{Path: f.Path, Action: actionArchived, SHA256: sha256Hex(f.Content)})
+		}
+		return io.Encode(out, outputFormat, report)
+	}
+	fmt.Fprintf(out, "✔ Archived %d files to %s\n", len(files), archiveFile)
+	return nil
+}
+
+// gitRecordPaths is the record of a run that --git-record stages, relative
+// to the output directory.
+var gitRecordPaths = []string{project.ConfigFile, project.LockFile, project.BaseDir}
+
+// generateGit checks --git-branch, --commit and --git-record. With
+// --git-branch, it returns the git working tree of the output directory,
+// refusing to start if the tree has uncommitted changes, since they would
+// follow to the new branch, or if the branch cannot be created.
+func generateGit() (*workspace.Git, error) {
+	if gitBranch == "" {
+		if gitCommit || gitRecord {
+			return nil, errors.New("--commit and --git-record require --git-branch")
+		}
+		return nil, nil
+	}
+	if dryRun || showDiff || format != formatDir {
+		return nil, errors.New("--git-branch cannot be combined with --dry-run, --diff or --format")
+	}
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, err
+	}
+	repo, err := workspace.NewGit(outputDir)
+	if err != nil {
+		return nil, err
+	}
+	dirty, err := repo.Dirty(gitRecordPaths...)
+	if err != nil {
+		return nil, err
+	}
+	if len(dirty) > 0 {
+		if len(dirty) > 3 {
+			dirty = append(dirty[:3], "...")
+		}
+		return nil, fmt.Errorf("the git working tree has uncommitted changes (%s): commit or stash them before generating onto a new branch", strings.Join(dirty, ", "))
+	}
+	if err := repo.CheckBranch(gitBranch); err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+// commitMessage returns the conventional-commit message written by
+// --commit, listing the generated components.
+func commitMessage(g project.Generation) string {
+	var sb strings.Builder
+	if g.Config != nil {
+		fmt.Fprintf(&sb, "feat(scaffold): generate %s scaffolding\n\n", g.Config.ProjectName)
+		fmt.Fprintf(&sb, "Components:\n- %s: %s\n", g.Config.ProjectName, componentParts(*g.Config))
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, "feat(scaffold): generate scaffolding for %d components\n\nComponents:\n", len(g.Components))
+	for _, c := range g.Components {
+		path := c.Path
+		if path == "" {
+			path = "."
+		}
+		fmt.Fprintf(&sb, "- %s (%s): %s\n", path, c.ProjectName, componentParts(c.Config))
+	}
+	return sb.String()
+}
+
+// componentParts describes what cfg generates, e.g. "GitHub Actions (go),
+// Docker".
+func componentParts(cfg scaffold.Config) string {
+	var parts []string
+	if cfg.WithActions {
+		workflowType := cfg.WorkflowType
+		if workflowType == "" {
+			workflowType = "go"
+		}
+		parts = append(parts, fmt.Sprintf("GitHub Actions (%s)", workflowType))
+	}
+	if cfg.WithDocker || cfg.UseDocker {
+		parts = append(parts, "Docker")
+	}
+	if cfg.WithFlux {
+		parts = append(parts, "Flux")
+	}
+	if len(parts) == 0 {
+		return "no templates"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// writeArchive packs files into an archive written to w.
+func writeArchive(w stdio.Writer, archiveFormat string, files []scaffold.File) error {
+	sink := workspace.NewArchive(w, archiveFormat)
+	if _, err := workspace.Write(sink, files, workspace.Options{}); err != nil {
+		return err
+	}
+	return sink.Close()
+}
+
+func sha256Hex(content string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
+}
+
+// generateProject renders the single project described by the project file
+// in the output directory, with the flags given on the command line applied
+// on top. Without a project file, every flag applies. It also returns the
+// configuration used, to be recorded.
+func generateProject(cmd *cobra.Command) ([]scaffold.File, project.Generation, error) {
+	cfg, err := project.Load(outputDir)
+	found := err == nil
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, project.Generation{}, err
+	}
+
+	flags := cmd.Flags()
+	apply := func(name string) bool { return !found || flags.Changed(name) }
+	for _, f := range []struct {
+		flag string
+		dst  *string
+		v    string
+	}{
+		{"project-name", &cfg.ProjectName, projectName},
+		{"workflow-type", &cfg.WorkflowType, workflowType},
+		{"org", &cfg.Org, org},
+		{"registry", &cfg.Registry, registry},
+		{"branch", &cfg.Branch, branch},
+	} {
+		if apply(f.flag) {
+			*f.dst = f.v
+		}
+	}
+	if apply("with-docker") || apply("docker") {
+		cfg.WithDocker = withDocker
+		cfg.UseDocker = withDocker || useDocker // Support both for now
+	}
+	if apply("with-actions") {
+		cfg.WithActions = withActions
+	}
+	if apply("with-flux") {
+		cfg.WithFlux = withFlux
+	}
+	if apply("environments") {
+		cfg.Environments = environments
+	}
+	if apply("var") && len(variables) > 0 {
+		if cfg.Variables == nil {
+			cfg.Variables = make(map[string]string)
+		}
+		maps.Copy(cfg.Variables, variables)
+	}
+	// The "workflows" subcommand implies --with-actions.
+	if cmd.Name() == "workflows" {
+		cfg.WithActions = true
+	}
+
+	if cfg.ProjectName == "" {
+		dir, _ := os.Getwd()
+		cfg.ProjectName = filepath.Base(dir)
+	}
+
+	// Use ProjectGenerator for multi-component scaffolding
+	gen := scaffold.NewProjectGenerator()
+	files, err := gen.Generate(cmd.Context(), cfg)
+	if err != nil {
+		return nil, project.Generation{}, fmt.Errorf("failed to generate scaffold: %w", err)
+	}
+	return files, project.Generation{Config: &cfg}, nil
+}
+
+// batchSpec is the format of a --batch file. It uses the same field names
+// as the generate_batch MCP tool.
+type batchSpec struct {
+	Components []scaffold.Component `json:"components"`
+}
+
+// generateBatch renders every component listed in a YAML or JSON batch file,
+// and returns the configuration used, to be recorded.
+func generateBatch(ctx context.Context, file string) ([]scaffold.File, project.Generation, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, project.Generation{}, fmt.Errorf("failed to read batch file: %w", err)
+	}
+
+	// Decode as YAML (a superset of JSON), then map onto the JSON field names.
+	var raw any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, project.Generation{}, fmt.Errorf("failed to parse batch file %s: %w", file, err)
+	}
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, project.Generation{}, fmt.Errorf("failed to parse batch file %s: %w", file, err)
+	}
+	var spec batchSpec
+	if err := json.Unmarshal(encoded, &spec); err != nil {
+		return nil, project.Generation{}, fmt.Errorf("failed to parse batch file %s: %w", file, err)
+	}
+
+	files, err := scaffold.GenerateBatch(ctx, spec.Components)
+	if err != nil {
+		return nil, project.Generation{}, fmt.Errorf("failed to generate scaffold: %w", err)
+	}
+	return files, project.Generation{Components: spec.Components}, nil
+}
+
 var workflowsCmd = &cobra.Command{
 	Use:   "workflows",
 	Short: "Generate GitHub Actions workflow files",
@@ -96,6 +599,15 @@ var workflowsCmd = &cobra.Command{
 	},
 }
 
+// confirmOverwrite asks before replacing an existing file, and refuses outright
+// when there is no terminal to ask on.
+func confirmOverwrite(path string) (bool, error) {
+	if !isTerminal() {
+		return false, fmt.Errorf("file %s already exists, use --force to overwrite (non-interactive mode)", path)
+	}
+	return io.Confirm(fmt.Sprintf("? File %s already exists. Overwrite?", path)), nil
+}
+
 func isTerminal() bool {
 	stat, _ := os.Stdin.Stat()
 	return (stat.Mode() & os.ModeCharDevice) != 0
@@ -107,15 +619,31 @@ func init() {
 
 	// Shared flags (Persistent across generate and subcommands)
 	generateCmd.PersistentFlags().StringVarP(&projectName, "project-name", "p", "", "Name of the project")
-	generateCmd.PersistentFlags().StringVarP(&outputDir, "output", "o", ".", "Target directory")
+	generateCmd.PersistentFlags().StringVarP(&outputDir, "dir", "o", ".", "Target directory")
+	// -o was the shorthand of the target directory flag before --output
+	// selected the output format; it keeps that meaning until it is removed.
+	_ = generateCmd.PersistentFlags().MarkShorthandDeprecated("dir", "use --dir")
 	generateCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Preview only")
-	generateCmd.PersistentFlags().BoolVarP(&force, "force", "f", false, "Overwrite existing files")
+	generateCmd.PersistentFlags().BoolVar(&showDiff, "diff", false, "Show a unified diff against existing files without writing")
+	generateCmd.PersistentFlags().StringVar(&format, "format", formatDir, "Output format: dir (write into --dir), tar, zip or stdout-multidoc")
+	generateCmd.PersistentFlags().StringVar(&archiveFile, "archive-file", "", "File to write the --format tar or zip archive to (default stdout)")
+	generateCmd.PersistentFlags().BoolVarP(&force, "force", "f", false, "Overwrite existing files; shorthand for --on-conflict overwrite")
+	generateCmd.PersistentFlags().StringVar(&gitBranch, "git-branch", "", "Create this branch in the git repository of --dir and write the files onto it; the working tree must be clean")
+	generateCmd.PersistentFlags().BoolVar(&gitCommit, "commit", false, "With --git-branch, commit the generated files with a conventional-commit message")
+	generateCmd.PersistentFlags().BoolVar(&gitRecord, "git-record", false, "With --git-branch, also stage the record of the run under .platform: config, lockfile and stored bases")
+	generateCmd.PersistentFlags().StringVar(&onConflict, "on-conflict", "", "What to do with existing files that differ: skip, overwrite, prompt, fail or merge (default prompt, or skip with --output json or yaml)")
 	generateCmd.PersistentFlags().StringVarP(&workflowType, "workflow-type", "t", "go", "Type of workflow (go, typescript, node, python)")
+	generateCmd.PersistentFlags().StringVar(&org, "org", "", "GitHub organization used in Flux source URLs (default myorg)")
+	generateCmd.PersistentFlags().StringVar(&registry, "registry", "", "Container registry to push images to, e.g. ghcr.io")
+	generateCmd.PersistentFlags().StringVar(&branch, "branch", "", "Branch that workflows run on and Flux tracks")
+	generateCmd.PersistentFlags().StringToStringVar(&variables, "var", nil, "Extra template variable as name=value (repeatable), available as {{ .Variables.name }}")
+	generateCmd.PersistentFlags().StringSliceVar(&environments, "environments", nil, "Comma-separated deployment environments; Flux gets one Kustomization per environment")
 
 	// Generate specific flags
 	generateCmd.Flags().BoolVar(&withDocker, "with-docker", false, "Include Dockerfile")
 	generateCmd.Flags().BoolVar(&withActions, "with-actions", false, "Include GitHub Actions")
 	generateCmd.Flags().BoolVar(&withFlux, "with-flux", false, "Include FluxCD manifests")
+	generateCmd.Flags().StringVar(&batchFile, "batch", "", "YAML or JSON file listing components to generate, each with its own path and options")
 
 	// Legacy flags for workflows command (aliased or hidden if needed)
 	// Since workflows inherits persistent flags, we don't need to re-add them.
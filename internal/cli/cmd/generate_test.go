@@ -1,14 +1,54 @@
 package cmd
 
 import (
+	"archive/tar"
 	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	stdio "io"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/modelcontextprotocol/platform.mcp/internal/project"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
+// resetGenerateFlags restores the package-level flag values to their defaults
+// and marks every flag as not given.
+func resetGenerateFlags() {
+	for _, fs := range []*pflag.FlagSet{generateCmd.Flags(), generateCmd.PersistentFlags(), workflowsCmd.Flags()} {
+		fs.VisitAll(func(f *pflag.Flag) { f.Changed = false })
+	}
+	projectName = ""
+	workflowType = "go"
+	useDocker = false
+	withDocker = false
+	withActions = false
+	withFlux = false
+	dryRun = false
+	showDiff = false
+	force = false
+	outputDir = "."
+	batchFile = ""
+	org = ""
+	registry = ""
+	branch = ""
+	environments = nil
+	variables = nil
+	format = formatDir
+	archiveFile = ""
+	onConflict = ""
+	gitBranch = ""
+	gitCommit = false
+	gitRecord = false
+}
+
 func TestGenerateCommand(t *testing.T) {
 	cases := []struct {
 		name          string
@@ -72,20 +112,26 @@ func TestGenerateCommand(t *testing.T) {
 				"fluxcd.yaml",
 			},
 		},
+		{
+			name:    "org-registry-branch",
+			args:    []string{"generate", "--project-name", "org-test", "--with-flux", "--with-docker", "--org", "acme", "--registry", "ghcr.io", "--branch", "trunk"},
+			wantErr: false,
+			expectedFiles: []string{
+				"fluxcd.yaml",
+				"docker-build.yaml",
+			},
+		},
+		{
+			name:    "invalid-registry",
+			args:    []string{"generate", "--project-name", "org-test", "--registry", "https://ghcr.io"},
+			wantErr: true,
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			// Reset flag values before each test to avoid state pollution
-			projectName = ""
-			workflowType = "go"
-			useDocker = false
-			withDocker = false
-			withActions = false
-			withFlux = false
-			dryRun = false
-			force = false
-			outputDir = "."
+			resetGenerateFlags()
 
 			tmpDir, err := os.MkdirTemp("", "platform-test-*")
 			if err != nil {
@@ -101,7 +147,7 @@ func TestGenerateCommand(t *testing.T) {
 			root.SetErr(buf)
 
 			// Add output dir to args
-			args := append(tc.args, "--output", tmpDir)
+			args := append(tc.args, "--dir", tmpDir)
 			root.SetArgs(args)
 
 			err = root.Execute()
@@ -120,3 +166,525 @@ func TestGenerateCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateCommand_Diff(t *testing.T) {
+	resetGenerateFlags()
+	defer resetGenerateFlags()
+
+	tmpDir := t.TempDir()
+
+	root := &cobra.Command{Use: "platform"}
+	root.AddCommand(generateCmd)
+	root.SetArgs([]string{"generate", "--project-name", "diff-test", "--with-actions", "--diff", "--dir", tmpDir})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("--diff must not write files, found %d entries", len(entries))
+	}
+}
+
+func TestGenerateCommand_OnConflict(t *testing.T) {
+	resetGenerateFlags()
+	defer resetGenerateFlags()
+	tmpDir := t.TempDir()
+
+	run := func(args ...string) (string, error) {
+		t.Helper()
+		resetGenerateFlags()
+		root := &cobra.Command{Use: "platform"}
+		root.AddCommand(generateCmd)
+		var stdout bytes.Buffer
+		root.SetOut(&stdout)
+		root.SetErr(stdio.Discard)
+		root.SetArgs(append([]string{"generate", "--project-name", "api", "--with-actions", "--dir", tmpDir, "--on-conflict", "skip"}, args...))
+		err := root.Execute()
+		return stdout.String(), err
+	}
+
+	if _, err := run(); err != nil {
+		t.Fatal(err)
+	}
+	workflow := filepath.Join(tmpDir, ".github", "workflows", "go.yaml")
+	generated, err := os.ReadFile(workflow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(workflow, append(generated, "# local edit\n"...), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := run("--on-conflict", "fail", "--branch", "develop"); err == nil {
+		t.Error("--on-conflict fail: expected an error for the edited file")
+	}
+	if _, err := run("--force", "--on-conflict", "merge"); err == nil {
+		t.Error("--force with --on-conflict merge: expected an error")
+	}
+
+	// The template change and the local edit are both kept.
+	out, err := run("--on-conflict", "merge", "--branch", "develop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "✔ Merged "+workflow) {
+		t.Errorf("output lacks the merge:\n%s", out)
+	}
+	merged, err := os.ReadFile(workflow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(merged), "develop") || !strings.HasSuffix(string(merged), "# local edit\n") {
+		t.Errorf("merged workflow:\n%s", merged)
+	}
+	out, err = run("--on-conflict", "overwrite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "✔ Overwrote "+workflow) || !strings.Contains(out, "Generation complete! 2 files written.") {
+		t.Errorf("output lacks the overwrite:\n%s", out)
+	}
+}
+
+func TestGenerateCommand_Rollback(t *testing.T) {
+	resetGenerateFlags()
+	defer resetGenerateFlags()
+	defer func() { outputFormat = "text" }()
+	tmpDir := t.TempDir()
+
+	// A directory where a workflow should go makes the write fail.
+	if err := os.MkdirAll(filepath.Join(tmpDir, ".github", "workflows", "go.yaml"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	root := &cobra.Command{Use: "platform"}
+	addOutputFlag(root)
+	root.AddCommand(generateCmd)
+	var stdout bytes.Buffer
+	root.SetOut(&stdout)
+	root.SetErr(stdio.Discard)
+	root.SetArgs([]string{"generate", "--project-name", "api", "--with-actions", "--dir", tmpDir, "--force", "--output", "json"})
+	if err := root.Execute(); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	var report struct {
+		Files []struct{ Path, Action, Error string } `json:"files"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout.String())
+	}
+	actions := make(map[string]string)
+	for _, f := range report.Files {
+		actions[f.Path] = f.Action
+	}
+	if actions[".github/workflows/ci.yaml"] != "rolled-back" || actions[".github/workflows/go.yaml"] != "failed" {
+		t.Errorf("report = %+v", report.Files)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, ".github", "workflows", "ci.yaml")); !os.IsNotExist(err) {
+		t.Errorf("ci.yaml was written although generation failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, project.LockFile)); !os.IsNotExist(err) {
+		t.Errorf("a failed generation was recorded: %v", err)
+	}
+}
+
+func TestGenerateCommand_GitBranch(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	resetGenerateFlags()
+	defer resetGenerateFlags()
+	tmpDir := t.TempDir()
+
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = tmpDir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	git("init", "-q")
+	git("config", "user.name", "Test")
+	git("config", "user.email", "test@example.com")
+	if err := os.WriteFile(filepath.Join(tmpDir, "README.md"), []byte("# api\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", "README.md")
+	git("commit", "-q", "-m", "initial")
+
+	run := func(args ...string) (string, error) {
+		t.Helper()
+		resetGenerateFlags()
+		root := &cobra.Command{Use: "platform"}
+		root.AddCommand(generateCmd)
+		var stdout bytes.Buffer
+		root.SetOut(&stdout)
+		root.SetErr(stdio.Discard)
+		root.SetArgs(append([]string{"generate", "--project-name", "api", "--with-actions", "--with-docker", "--dir", tmpDir}, args...))
+		err := root.Execute()
+		return stdout.String(), err
+	}
+
+	if _, err := run("--commit"); err == nil {
+		t.Error("--commit without --git-branch: expected an error")
+	}
+
+	// A dirty working tree is refused before anything happens.
+	if err := os.WriteFile(filepath.Join(tmpDir, "notes.txt"), []byte("wip\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run("--git-branch", "scaffold", "--commit"); err == nil || !strings.Contains(err.Error(), "notes.txt") {
+		t.Errorf("dirty tree: err = %v", err)
+	}
+	if branch := git("branch", "--show-current"); branch == "scaffold" {
+		t.Error("the branch was created although the tree is dirty")
+	}
+	if err := os.Remove(filepath.Join(tmpDir, "notes.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	// A failed write leaves no branch behind. Git does not track the empty
+	// directory in the way of the Dockerfile, so the tree is clean.
+	initial := git("branch", "--show-current")
+	if err := os.Mkdir(filepath.Join(tmpDir, "Dockerfile"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run("--git-branch", "scaffold", "--commit"); err == nil {
+		t.Error("write into a directory: expected an error")
+	}
+	if branch := git("branch", "--show-current"); branch != initial {
+		t.Errorf("on branch %q after a failed write, want %q", branch, initial)
+	}
+	if branches := git("branch", "--list", "scaffold"); branches != "" {
+		t.Errorf("the branch was created although the write failed: %s", branches)
+	}
+	if err := os.Remove(filepath.Join(tmpDir, "Dockerfile")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(filepath.Join(tmpDir, ".platform")); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := run("--git-branch", "scaffold", "--commit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "✔ Committed") {
+		t.Errorf("output lacks the commit:\n%s", out)
+	}
+	if branch := git("branch", "--show-current"); branch != "scaffold" {
+		t.Errorf("on branch %q, want scaffold", branch)
+	}
+	// The record of the run is left for --git-record.
+	if status := git("status", "--porcelain"); status != "?? .platform/" {
+		t.Errorf("working tree after commit:\n%s", status)
+	}
+	message := git("log", "-1", "--format=%B")
+	if !strings.HasPrefix(message, "feat(scaffold): generate api scaffolding\n") || !strings.Contains(message, "- api: GitHub Actions (go), Docker") {
+		t.Errorf("commit message:\n%s", message)
+	}
+	committed := git("show", "--name-only", "--format=", "HEAD")
+	for _, want := range []string{".github/workflows/ci.yaml", "Dockerfile"} {
+		if !strings.Contains(committed, want) {
+			t.Errorf("commit lacks %s:\n%s", want, committed)
+		}
+	}
+	if strings.Contains(committed, "README.md") || strings.Contains(committed, ".platform") {
+		t.Errorf("commit includes files that were not generated:\n%s", committed)
+	}
+
+	// The branch must be new. The record does not make the tree dirty, and
+	// --git-record commits it.
+	if _, err := run("--git-branch", "scaffold"); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("existing branch: err = %v", err)
+	}
+	// A staged record file would be committed, so it makes the tree dirty.
+	git("add", project.LockFile)
+	if _, err := run("--git-branch", "scaffold-staged", "--commit"); err == nil || !strings.Contains(err.Error(), project.LockFile) {
+		t.Errorf("staged record file: err = %v", err)
+	}
+	git("reset", "-q")
+	if out, err := run("--git-branch", "scaffold-record", "--git-record", "--commit"); err != nil {
+		t.Fatalf("--git-record = %v\n%s", err, out)
+	}
+	committed = git("show", "--name-only", "--format=", "HEAD")
+	for _, want := range []string{project.ConfigFile, project.LockFile, project.BaseDir + "/Dockerfile"} {
+		if !strings.Contains(committed, want) {
+			t.Errorf("commit lacks %s:\n%s", want, committed)
+		}
+	}
+	if status := git("status", "--porcelain"); status != "" {
+		t.Errorf("working tree not clean after --git-record:\n%s", status)
+	}
+}
+
+func TestGenerateCommand_Batch(t *testing.T) {
+	resetGenerateFlags()
+	defer resetGenerateFlags()
+
+	tmpDir := t.TempDir()
+	batch := filepath.Join(t.TempDir(), "components.yaml")
+	spec := `components:
+  - path: services/api
+    project_name: api
+    with_docker: true
+  - path: services/web
+    project_name: web
+    with_docker: true
+`
+	if err := os.WriteFile(batch, []byte(spec), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	root := &cobra.Command{Use: "platform"}
+	root.AddCommand(generateCmd)
+	root.SetArgs([]string{"generate", "--batch", batch, "--dir", tmpDir})
+
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	for _, want := range []string{"services/api/Dockerfile", "services/web/Dockerfile"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, want)); err != nil {
+			t.Errorf("expected %s: %v", want, err)
+		}
+	}
+}
+
+func TestGenerateCommand_ProjectFile(t *testing.T) {
+	resetGenerateFlags()
+	defer resetGenerateFlags()
+
+	tmpDir := t.TempDir()
+	err := project.Save(tmpDir, scaffold.Config{
+		ProjectName:  "api",
+		WorkflowType: "python",
+		WithActions:  true,
+		WithFlux:     true,
+		Environments: []string{"staging", "production"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) {
+		t.Helper()
+		root := &cobra.Command{Use: "platform"}
+		root.AddCommand(generateCmd)
+		root.SetArgs(append([]string{"generate", "--dir", tmpDir}, args...))
+		if err := root.Execute(); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+	}
+
+	// No flags: the project file alone describes the scaffold.
+	run()
+	for _, want := range []string{".github/workflows/python.yaml", "fluxcd.yaml"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, want)); err != nil {
+			t.Errorf("expected %s: %v", want, err)
+		}
+	}
+	flux, err := os.ReadFile(filepath.Join(tmpDir, "fluxcd.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(flux), "./deploy/production") {
+		t.Errorf("fluxcd.yaml does not use the environments from %s:\n%s", project.FileName, flux)
+	}
+
+	// Flags given on the command line override the file.
+	resetGenerateFlags()
+	run("--workflow-type", "go", "--force")
+	if _, err := os.Stat(filepath.Join(tmpDir, ".github/workflows/go.yaml")); err != nil {
+		t.Errorf("--workflow-type did not override the project file: %v", err)
+	}
+}
+
+func TestGenerateCommand_Output(t *testing.T) {
+	resetGenerateFlags()
+	defer resetGenerateFlags()
+	defer func() { outputFormat = "text" }()
+	tmpDir := t.TempDir()
+
+	run := func(args ...string) (string, string, error) {
+		t.Helper()
+		resetGenerateFlags()
+		root := &cobra.Command{Use: "platform"}
+		addOutputFlag(root)
+		root.AddCommand(generateCmd)
+		var stdout, stderr bytes.Buffer
+		root.SetOut(&stdout)
+		root.SetErr(&stderr)
+		root.SetArgs(append([]string{"generate", "--project-name", "api", "--with-actions"}, args...))
+		err := root.Execute()
+		return stdout.String(), stderr.String(), err
+	}
+	type report struct {
+		Directory string `json:"directory"`
+		Files     []struct {
+			Path, Action, SHA256, Error string
+		} `json:"files"`
+		Error string `json:"error"`
+	}
+	decode := func(out string) report {
+		t.Helper()
+		var r report
+		if err := json.Unmarshal([]byte(out), &r); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, out)
+		}
+		return r
+	}
+
+	out, _, err := run("--dir", tmpDir, "--output", "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := decode(out)
+	if r.Directory != tmpDir || len(r.Files) != 2 {
+		t.Fatalf("report = %+v", r)
+	}
+	for _, f := range r.Files {
+		content, err := os.ReadFile(filepath.Join(tmpDir, f.Path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.Action != "created" || f.SHA256 != fmt.Sprintf("%x", sha256.Sum256(content)) {
+			t.Errorf("file = %+v", f)
+		}
+	}
+
+	// Up-to-date files are reported as unchanged; edited ones are skipped
+	// without prompting.
+	if err := os.WriteFile(filepath.Join(tmpDir, ".github", "workflows", "ci.yaml"), []byte("edited\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out, _, err = run("--dir", tmpDir, "--output", "yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"path: .github/workflows/go.yaml\n    action: unchanged\n", "path: .github/workflows/ci.yaml\n    action: skipped\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("yaml report lacks %q:\n%s", want, out)
+		}
+	}
+
+	// Errors are part of the report.
+	out, _, err = run("--dir", tmpDir, "--output", "json", "--registry", "https://ghcr.io")
+	if err == nil || !strings.Contains(decode(out).Error, "registry") {
+		t.Errorf("invalid registry = %v\n%s", err, out)
+	}
+
+	// -o still names the target directory, with a warning, and --output
+	// always selects the format, even if a directory has that name.
+	legacyDir := t.TempDir()
+	out, stderr, err := run("-o", legacyDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Cobra prints flag deprecations to the command's output, stderr unless
+	// it is set.
+	if !strings.Contains(out+stderr, "-o has been deprecated, use --dir") || !strings.Contains(out, "Generation complete!") {
+		t.Errorf("legacy -o: stdout %q, stderr %q", out, stderr)
+	}
+	if _, err := os.Stat(filepath.Join(legacyDir, ".github", "workflows", "ci.yaml")); err != nil {
+		t.Errorf("legacy -o did not write to the directory: %v", err)
+	}
+	if _, _, err := run("--output", legacyDir); err == nil || !strings.Contains(err.Error(), "unsupported output format") {
+		t.Errorf("--output <directory> = %v, want an unsupported format error", err)
+	}
+}
+
+func TestGenerateCommand_Format(t *testing.T) {
+	resetGenerateFlags()
+	defer resetGenerateFlags()
+	defer func() { outputFormat = "text" }()
+	tmpDir := t.TempDir()
+
+	run := func(args ...string) (string, error) {
+		t.Helper()
+		resetGenerateFlags()
+		root := &cobra.Command{Use: "platform"}
+		addOutputFlag(root)
+		root.AddCommand(generateCmd)
+		var stdout bytes.Buffer
+		root.SetOut(&stdout)
+		root.SetErr(stdio.Discard)
+		root.SetArgs(append([]string{"generate", "--project-name", "api", "--with-actions", "--dir", tmpDir}, args...))
+		err := root.Execute()
+		return stdout.String(), err
+	}
+
+	// A tar stream on stdout, and nothing written to the directory.
+	out, err := run("--format", "tar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(strings.NewReader(out))
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if err == stdio.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Mode != 0644 {
+			t.Errorf("%s has mode %o", hdr.Name, hdr.Mode)
+		}
+		names = append(names, hdr.Name)
+	}
+	if strings.Join(names, ",") != ".github/workflows/ci.yaml,.github/workflows/go.yaml" {
+		t.Errorf("archived %v", names)
+	}
+	if entries, _ := os.ReadDir(tmpDir); len(entries) != 0 {
+		t.Errorf("--format tar wrote into the directory: %v", entries)
+	}
+
+	out, err = run("--format", "stdout-multidoc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out, "--- FILE: .github/workflows/ci.yaml ---\n") || !strings.Contains(out, "--- FILE: .github/workflows/go.yaml ---\n") {
+		t.Errorf("multidoc output:\n%s", out)
+	}
+
+	zipFile := filepath.Join(t.TempDir(), "scaffold.zip")
+	out, err = run("--format", "zip", "--archive-file", zipFile, "--output", "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report struct {
+		Archive string `json:"archive"`
+		Files   []struct{ Action string }
+	}
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if report.Archive != zipFile || len(report.Files) != 2 || report.Files[0].Action != "archived" {
+		t.Errorf("report = %+v", report)
+	}
+	if _, err := os.Stat(zipFile); err != nil {
+		t.Error(err)
+	}
+
+	for _, args := range [][]string{
+		{"--format", "rar"},
+		{"--format", "tar", "--dry-run"},
+		{"--format", "tar", "--output", "json"},
+		{"--archive-file", zipFile},
+	} {
+		if _, err := run(args...); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+}
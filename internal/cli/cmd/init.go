@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/platform.mcp/internal/cli/io"
+	"github.com/modelcontextprotocol/platform.mcp/internal/detect"
+	"github.com/modelcontextprotocol/platform.mcp/internal/project"
+	"github.com/modelcontextprotocol/platform.mcp/internal/workspace"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+	"github.com/spf13/cobra"
+)
+
+var initYes bool
+
+var initCmd = &cobra.Command{
+	Use:   "init [directory]",
+	Short: "Interactively set up a project scaffold",
+	Long: `Ask for the project name, language, components, environments and
+container registry, with defaults detected from the directory (or taken from
+the answers saved by an earlier run), then preview and write the files.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := "."
+		if len(args) == 1 {
+			dir = args[0]
+		}
+		out := cmd.OutOrStdout()
+		p := io.NewPrompter(cmd.InOrStdin(), out)
+
+		cfg, err := initDefaults(dir)
+		if err != nil {
+			return err
+		}
+		if !initYes {
+			if cfg, err = askConfig(p, cfg); err != nil {
+				return err
+			}
+		}
+
+		files, err := scaffold.NewProjectGenerator().Generate(cmd.Context(), cfg)
+		if err != nil {
+			return fmt.Errorf("failed to generate scaffold: %w", err)
+		}
+		diffs, err := workspace.Compare(dir, files)
+		if err != nil {
+			return fmt.Errorf("failed to compare scaffold: %w", err)
+		}
+		// Only new and changed files are written.
+		var pending []scaffold.File
+		fmt.Fprintf(out, "\nFiles for %s:\n", cfg.ProjectName)
+		for i, d := range diffs {
+			fmt.Fprintf(out, "  %-9s %s\n", d.Status, d.Path)
+			if d.Status != workspace.StatusUnchanged {
+				pending = append(pending, files[i])
+			}
+		}
+
+		write, save := len(pending) > 0, true
+		if !initYes {
+			if write {
+				if write, err = p.Confirm("Write these files?", true); err != nil {
+					return err
+				}
+			}
+			if save, err = p.Confirm(fmt.Sprintf("Save these answers to %s for later runs?", project.FileName), true); err != nil {
+				return err
+			}
+		}
+
+		if write {
+			opts := workspace.Options{Policy: workspace.PolicyPrompt, Prompt: func(path string) (bool, error) {
+				return p.Confirm(fmt.Sprintf("File %s already exists. Overwrite?", filepath.Join(dir, path)), false)
+			}}
+			if initYes {
+				opts.Policy = workspace.PolicySkip
+			}
+			results, err := workspace.Write(workspace.Dir(dir), pending, opts)
+			printResults(out, dir, results)
+			if err != nil {
+				return err
+			}
+			if err := project.Record(dir, project.Generation{Config: &cfg}, files, results); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "✔ Recorded %s\n", filepath.Join(dir, project.LockFile))
+		}
+		if save {
+			if err := project.Save(dir, cfg); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "✔ Saved answers to %s\n", filepath.Join(dir, project.FileName))
+		}
+		return nil
+	},
+}
+
+// initDefaults returns the project file in dir, or the configuration
+// detected from its contents if there is none.
+func initDefaults(dir string) (scaffold.Config, error) {
+	cfg, err := project.Load(dir)
+	if err == nil {
+		return cfg, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return scaffold.Config{}, err
+	}
+
+	report, err := detect.Analyze(dir)
+	if err != nil {
+		return scaffold.Config{}, err
+	}
+	return report.Config, nil
+}
+
+// Component choices offered by init, in the order they are listed.
+var initComponents = []string{"GitHub Actions workflows", "Docker image", "Flux CD manifests"}
+
+// askConfig walks the user through the wizard, starting from cfg.
+func askConfig(p *io.Prompter, cfg scaffold.Config) (scaffold.Config, error) {
+	var err error
+	validate := func(apply func(*scaffold.Config, string)) func(string) error {
+		return func(answer string) error {
+			c := cfg
+			apply(&c, answer)
+			return scaffold.ValidateConfig(c)
+		}
+	}
+
+	setName := func(c *scaffold.Config, v string) { c.ProjectName = v }
+	if cfg.ProjectName, err = p.Text("Project name", cfg.ProjectName, validate(setName)); err != nil {
+		return cfg, err
+	}
+
+	languages := []string{"go", "typescript", "python"}
+	current := cfg.WorkflowType
+	if current == "node" {
+		current = "typescript"
+	}
+	def := max(slices.Index(languages, current), 0)
+	choice, err := p.Select("Language", languages, def)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.WorkflowType = languages[choice]
+
+	var enabled []int
+	for i, on := range []bool{cfg.WithActions, cfg.WithDocker || cfg.UseDocker, cfg.WithFlux} {
+		if on {
+			enabled = append(enabled, i)
+		}
+	}
+	if enabled, err = p.MultiSelect("Components to include", initComponents, enabled); err != nil {
+		return cfg, err
+	}
+	cfg.WithActions = slices.Contains(enabled, 0)
+	cfg.WithDocker = slices.Contains(enabled, 1)
+	cfg.UseDocker = cfg.WithDocker
+	cfg.WithFlux = slices.Contains(enabled, 2)
+
+	if cfg.WithFlux {
+		setEnvironments := func(c *scaffold.Config, v string) { c.Environments = splitAnswer(v) }
+		answer, err := p.Text(`Environments (comma-separated, or "none")`, orNone(strings.Join(cfg.Environments, ",")), validate(setEnvironments))
+		if err != nil {
+			return cfg, err
+		}
+		setEnvironments(&cfg, answer)
+	}
+
+	if cfg.WithDocker {
+		setRegistry := func(c *scaffold.Config, v string) { c.Registry = noneAnswer(v) }
+		validateRegistry := func(answer string) error {
+			if strings.Contains(answer, ",") {
+				return errors.New("enter a single registry, e.g. ghcr.io")
+			}
+			return validate(setRegistry)(answer)
+		}
+		answer, err := p.Text(`Container registry to push to (e.g. ghcr.io, or "none")`, orNone(cfg.Registry), validateRegistry)
+		if err != nil {
+			return cfg, err
+		}
+		setRegistry(&cfg, answer)
+	}
+	return cfg, nil
+}
+
+func orNone(v string) string {
+	if v == "" {
+		return "none"
+	}
+	return v
+}
+
+// noneAnswer trims a single-value answer; "none" is empty.
+func noneAnswer(answer string) string {
+	answer = strings.TrimSpace(answer)
+	if strings.EqualFold(answer, "none") {
+		return ""
+	}
+	return answer
+}
+
+// splitAnswer splits a comma-separated answer; "none" is an empty list.
+func splitAnswer(answer string) []string {
+	if noneAnswer(answer) == "" {
+		return nil
+	}
+	var items []string
+	for _, item := range strings.Split(answer, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func init() {
+	rootCmd.AddCommand(initCmd)
+	initCmd.Flags().BoolVarP(&initYes, "yes", "y", false, "Accept the detected or saved defaults without asking, and write and save them")
+}
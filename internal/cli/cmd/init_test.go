@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/platform.mcp/internal/project"
+	"github.com/spf13/cobra"
+)
+
+func runInit(t *testing.T, input string, args ...string) (string, error) {
+	t.Helper()
+	defer func() { initYes = false }()
+	var out bytes.Buffer
+	root := &cobra.Command{Use: "platform"}
+	root.AddCommand(initCmd)
+	root.SetIn(strings.NewReader(input))
+	root.SetOut(&out)
+	root.SetErr(&out)
+	root.SetArgs(append([]string{"init"}, args...))
+	err := root.Execute()
+	return out.String(), err
+}
+
+func TestInitCommand(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/billing\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Name (invalid, then default), language, components, environments,
+	// registry (a list, then one), write, save.
+	input := "not valid!\n\n3\n1,2,3\nstaging, production\nghcr.io, docker.io\n ghcr.io/acme \ny\ny\n"
+	out, err := runInit(t, input, dir)
+	if err != nil {
+		t.Fatalf("init: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "project name must be alphanumeric") {
+		t.Errorf("invalid name not rejected:\n%s", out)
+	}
+	if !strings.Contains(out, "enter a single registry") {
+		t.Errorf("registry list not rejected:\n%s", out)
+	}
+	for _, want := range []string{"new       .github/workflows/python.yaml", "new       Dockerfile", "new       fluxcd.yaml"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("preview missing %q:\n%s", want, out)
+		}
+	}
+
+	flux, err := os.ReadFile(filepath.Join(dir, "fluxcd.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(flux), "path: ./deploy/production") {
+		t.Errorf("fluxcd.yaml has no production Kustomization:\n%s", flux)
+	}
+
+	saved, err := project.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.ProjectName != "billing" || saved.WorkflowType != "python" || !saved.WithFlux ||
+		saved.Registry != "ghcr.io/acme" || !slices.Equal(saved.Environments, []string{"staging", "production"}) {
+		t.Errorf("saved answers = %+v", saved)
+	}
+
+	// A later run starts from the saved answers; nothing needs writing.
+	out, err = runInit(t, "\n\n\n\n\nn\n", dir)
+	if err != nil {
+		t.Fatalf("second init: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "(staging,production)") || !strings.Contains(out, "unchanged fluxcd.yaml") {
+		t.Errorf("saved answers not used as defaults:\n%s", out)
+	}
+	if strings.Contains(out, "Write these files?") {
+		t.Errorf("asked to write unchanged files:\n%s", out)
+	}
+}
+
+func TestInitCommand_Yes(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "tsconfig.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runInit(t, "", "--yes", dir)
+	if err != nil {
+		t.Fatalf("init --yes: %v\n%s", err, out)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".github", "workflows", "typescript.yaml")); err != nil {
+		t.Errorf("detected workflow not written: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, project.FileName)); err != nil {
+		t.Errorf("answers not saved: %v", err)
+	}
+	if _, err := project.ReadLock(dir); err != nil {
+		t.Errorf("lockfile not written: %v", err)
+	}
+}
+
+func TestInitCommand_NoInput(t *testing.T) {
+	if _, err := runInit(t, "", t.TempDir()); err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Errorf("init without input = %v, want a hint to use --yes", err)
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/modelcontextprotocol/platform.mcp/internal/cli/io"
+	"github.com/modelcontextprotocol/platform.mcp/internal/project"
+	"github.com/spf13/cobra"
+)
+
+var lockCmd = &cobra.Command{
+	Use:   "lock",
+	Short: "Inspect the generation lockfile",
+	Long: `Every generate and init run records the resolved configuration, the
+template behind every file and the hash of every file in ` + project.LockFile + `.`,
+}
+
+var lockVerifyCmd = &cobra.Command{
+	Use:          "verify [directory]",
+	Short:        "Report generated files or templates that no longer match the lockfile",
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := "."
+		if len(args) == 1 {
+			dir = args[0]
+		}
+		out := cmd.OutOrStdout()
+
+		lock, err := project.ReadLock(dir)
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("no %s in %s: run platform generate first", project.LockFile, dir)
+		}
+		if err != nil {
+			return err
+		}
+		mismatches, err := project.VerifyLock(dir, lock)
+		if err != nil {
+			return err
+		}
+		if outputFormat != io.FormatText {
+			if mismatches == nil {
+				mismatches = []project.Mismatch{}
+			}
+			if err := io.Encode(out, outputFormat, struct {
+				Files      int                `json:"files"`
+				Mismatches []project.Mismatch `json:"mismatches"`
+			}{len(lock.Files), mismatches}); err != nil {
+				return err
+			}
+		} else {
+			for _, m := range mismatches {
+				fmt.Fprintf(out, "✘ %s: %s\n", m.Path, m.Reason)
+			}
+		}
+		if len(mismatches) > 0 {
+			return fmt.Errorf("%d of %d locked files do not match %s", len(mismatches), len(lock.Files), project.LockFile)
+		}
+		if outputFormat == io.FormatText {
+			fmt.Fprintf(out, "✔ All %d files match %s\n", len(lock.Files), project.LockFile)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(lockCmd)
+	lockCmd.AddCommand(lockVerifyCmd)
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestLockVerifyCommand(t *testing.T) {
+	resetGenerateFlags()
+	defer resetGenerateFlags()
+	dir := t.TempDir()
+
+	run := func(args ...string) (string, error) {
+		t.Helper()
+		root := &cobra.Command{Use: "platform"}
+		root.AddCommand(generateCmd, lockCmd)
+		buf := new(bytes.Buffer)
+		root.SetOut(buf)
+		root.SetErr(buf)
+		root.SetArgs(args)
+		err := root.Execute()
+		return buf.String(), err
+	}
+
+	if _, err := run("lock", "verify", dir); err == nil || !strings.Contains(err.Error(), "no .platform/lock.yaml") {
+		t.Errorf("verify without a lockfile = %v", err)
+	}
+
+	if _, err := run("generate", "--project-name", "api", "--with-actions", "--dir", dir); err != nil {
+		t.Fatal(err)
+	}
+	out, err := run("lock", "verify", dir)
+	if err != nil {
+		t.Fatalf("verify after generate = %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "✔ All 2 files match") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ".github", "workflows", "ci.yaml"), []byte("edited\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out, err = run("lock", "verify", dir)
+	if err == nil || !strings.Contains(out, "✘ .github/workflows/ci.yaml: file was modified") {
+		t.Errorf("verify after an edit = %v\n%s", err, out)
+	}
+}
+
+func TestLockVerifyCommand_Skipped(t *testing.T) {
+	resetGenerateFlags()
+	defer resetGenerateFlags()
+	dir := t.TempDir()
+
+	run := func(args ...string) (string, error) {
+		t.Helper()
+		checkFormat = ""
+		root := &cobra.Command{Use: "platform"}
+		root.AddCommand(generateCmd, lockCmd, checkCmd)
+		buf := new(bytes.Buffer)
+		root.SetOut(buf)
+		root.SetErr(buf)
+		root.SetArgs(args)
+		err := root.Execute()
+		return buf.String(), err
+	}
+
+	// A file of the user's own is kept by --on-conflict skip.
+	ciPath := filepath.Join(dir, ".github", "workflows", "ci.yaml")
+	if err := os.MkdirAll(filepath.Dir(ciPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(ciPath, []byte("mine\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := run("generate", "--project-name", "api", "--with-actions", "--on-conflict", "skip", "--dir", dir); err != nil || !strings.Contains(out, "Skipped "+ciPath) {
+		t.Fatalf("generate = %v\n%s", err, out)
+	}
+
+	if out, err := run("lock", "verify", dir); err != nil {
+		t.Errorf("verify after a skipping generate = %v\n%s", err, out)
+	}
+	if out, err := run("check", dir); err != nil {
+		t.Errorf("check after a skipping generate = %v\n%s", err, out)
+	}
+	// The skipped file was never given the generated content to merge from.
+	if _, err := os.Stat(filepath.Join(dir, ".platform", "base", ".github", "workflows", "ci.yaml")); !os.IsNotExist(err) {
+		t.Errorf("base of the skipped file: %v", err)
+	}
+
+	if err := os.WriteFile(ciPath, []byte("edited\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := run("lock", "verify", dir); err == nil || !strings.Contains(out, "ci.yaml: file was modified") {
+		t.Errorf("verify after an edit = %v\n%s", err, out)
+	}
+}
@@ -1,11 +1,20 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"os/signal"
+	"slices"
+	"strings"
 
+	"github.com/modelcontextprotocol/platform.mcp/internal/cli/io"
 	"github.com/spf13/cobra"
 )
 
+// outputFormat is the global --output flag.
+var outputFormat string
+
 var rootCmd = &cobra.Command{
 	Use:   "platform",
 	Short: "Platform CLI tool for generating project scaffolds",
@@ -14,13 +23,32 @@ to disk, using the shared core library.`,
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
+// Interrupting the process cancels the command's context.
 func Execute() {
-	err := rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := rootCmd.ExecuteContext(ctx)
 	if err != nil {
 		os.Exit(1)
 	}
 }
 
+// addOutputFlag adds the global --output flag to root and checks its value
+// before any command runs. It has no shorthand: -o still names the target
+// directory of generate.
+func addOutputFlag(root *cobra.Command) {
+	root.PersistentFlags().StringVar(&outputFormat, "output", io.FormatText, "Output format: "+strings.Join(io.Formats, ", "))
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if !slices.Contains(io.Formats, outputFormat) {
+			return fmt.Errorf("unsupported output format %q (%s)", outputFormat, strings.Join(io.Formats, ", "))
+		}
+		// Errors past this point are not usage errors.
+		cmd.SilenceUsage = true
+		return nil
+	}
+}
+
 func init() {
-	// Root flags if any
+	addOutputFlag(rootCmd)
 }
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/modelcontextprotocol/platform.mcp/internal/cli/io"
+	"github.com/modelcontextprotocol/platform.mcp/internal/project"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// upgradeRemoved reports a file that was generated before but that the
+// templates no longer produce. It is left in place.
+const upgradeRemoved = "removed"
+
+var upgradeCmd = &cobra.Command{
+	Use:   "upgrade [directory]",
+	Short: "Merge template changes into previously generated files",
+	Long: `Render the current templates with the configuration recorded in
+` + project.LockFile + ` and three-way merge the changes into the generated files,
+using the copies stored in ` + project.BaseDir + ` by the last run as the base.
+Local edits are kept; where a region was changed both locally and in the
+template, the file gets conflict markers to resolve by hand. The files are
+written all or nothing, and only then are the lockfile and the stored copies
+updated.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := "."
+		if len(args) == 1 {
+			dir = args[0]
+		}
+		out := cmd.OutOrStdout()
+
+		lock, err := project.ReadLock(dir)
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("no %s in %s: run platform generate first", project.LockFile, dir)
+		}
+		if err != nil {
+			return err
+		}
+
+		files, err := lock.Generate(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("failed to generate scaffold: %w", err)
+		}
+
+		results, err := project.Upgrade(dir, lock.Generation, files)
+		// Files the templates no longer produce are left in place.
+		for _, f := range lock.Files {
+			if !slices.ContainsFunc(files, func(g scaffold.File) bool { return g.Path == f.Path }) {
+				results = append(results, project.UpgradeResult{Path: f.Path, Status: upgradeRemoved})
+			}
+		}
+		conflicts := 0
+		for _, r := range results {
+			if r.Status == project.UpgradeConflict {
+				conflicts++
+			}
+			if outputFormat == io.FormatText {
+				fmt.Fprintf(out, "  %-9s %s\n", r.Status, r.Path)
+			}
+		}
+		if outputFormat != io.FormatText {
+			if err := io.Encode(out, outputFormat, struct {
+				Files []project.UpgradeResult `json:"files"`
+			}{results}); err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if conflicts > 0 {
+			return fmt.Errorf("%d files have conflicts: resolve the <<<<<<< markers and commit", conflicts)
+		}
+		if outputFormat == io.FormatText {
+			fmt.Fprintln(out, "✔ Upgrade complete")
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(upgradeCmd)
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/platform.mcp/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+func TestUpgradeCommand(t *testing.T) {
+	resetGenerateFlags()
+	defer resetGenerateFlags()
+	dir := t.TempDir()
+
+	run := func(args ...string) (string, error) {
+		t.Helper()
+		root := &cobra.Command{Use: "platform"}
+		root.AddCommand(generateCmd, upgradeCmd)
+		buf := new(bytes.Buffer)
+		root.SetOut(buf)
+		root.SetErr(buf)
+		root.SetArgs(args)
+		err := root.Execute()
+		return buf.String(), err
+	}
+
+	if _, err := run("generate", "--project-name", "api", "--with-actions", "--dir", dir); err != nil {
+		t.Fatal(err)
+	}
+	ciPath := filepath.Join(dir, ".github", "workflows", "ci.yaml")
+	original, err := os.ReadFile(ciPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited := "# maintained by the api team\n" + string(original)
+	if err := os.WriteFile(ciPath, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The platform team appends a job to the CI template.
+	layer := t.TempDir()
+	tmpl, err := templates.Load("workflow.yaml.tmpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(layer, "workflow.yaml.tmpl"), []byte(tmpl+"# audited\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := templates.SetLayers([]string{layer}); err != nil {
+		t.Fatal(err)
+	}
+	defer templates.Activate(nil)
+
+	out, err := run("upgrade", dir)
+	if err != nil {
+		t.Fatalf("upgrade = %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "merged    .github/workflows/ci.yaml") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+	got, err := os.ReadFile(ciPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != edited+"# audited\n" {
+		t.Errorf("ci.yaml = %q, want the local edit and the template change", got)
+	}
+}
@@ -0,0 +1,34 @@
+package io
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/modelcontextprotocol/platform.mcp/internal/jsonyaml"
+)
+
+// Output formats selected with the global --output flag.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// Formats lists the accepted output formats.
+var Formats = []string{FormatText, FormatJSON, FormatYAML}
+
+// Encode writes v to w as JSON or YAML. Both use the JSON field names, so
+// scripts can switch formats without changing their queries.
+func Encode(w io.Writer, format string, v any) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case FormatYAML:
+		return jsonyaml.Encode(w, v)
+	default:
+		return fmt.Errorf("format %q cannot encode values", format)
+	}
+}
@@ -0,0 +1,34 @@
+package io
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	v := struct {
+		Path   string   `json:"path"`
+		Action string   `json:"action,omitempty"`
+		Tags   []string `json:"tags"`
+	}{Path: "ci.yaml", Tags: []string{"a", "b"}}
+
+	var out bytes.Buffer
+	if err := Encode(&out, FormatJSON, v); err != nil {
+		t.Fatal(err)
+	}
+	if want := "{\n  \"path\": \"ci.yaml\",\n  \"tags\": [\n    \"a\",\n    \"b\"\n  ]\n}\n"; out.String() != want {
+		t.Errorf("json = %q, want %q", out.String(), want)
+	}
+
+	out.Reset()
+	if err := Encode(&out, FormatYAML, v); err != nil {
+		t.Fatal(err)
+	}
+	if want := "path: ci.yaml\ntags:\n  - a\n  - b\n"; out.String() != want {
+		t.Errorf("yaml = %q, want %q", out.String(), want)
+	}
+
+	if err := Encode(&out, FormatText, v); err == nil {
+		t.Error("Encode(text) succeeded")
+	}
+}
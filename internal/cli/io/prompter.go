@@ -2,8 +2,12 @@ package io
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 )
 
@@ -24,3 +28,169 @@ func Confirm(prompt string) bool {
 
 	return false
 }
+
+// ErrNoInput is returned by Prompter methods when the input ends before the
+// user answered.
+var ErrNoInput = errors.New("no input: run interactively or pass --yes to accept the defaults")
+
+// Prompter asks line-based questions on an input and output stream. Every
+// question has a default, chosen by answering with an empty line.
+type Prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// NewPrompter returns a Prompter reading answers from in and writing
+// questions to out.
+func NewPrompter(in io.Reader, out io.Writer) *Prompter {
+	return &Prompter{in: bufio.NewReader(in), out: out}
+}
+
+// readLine reads one answer, trimmed.
+func (p *Prompter) readLine() (string, error) {
+	line, err := p.in.ReadString('\n')
+	if err != nil && (line == "" || !errors.Is(err, io.EOF)) {
+		if errors.Is(err, io.EOF) {
+			fmt.Fprintln(p.out)
+			return "", ErrNoInput
+		}
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// Confirm asks a yes/no question.
+func (p *Prompter) Confirm(prompt string, def bool) (bool, error) {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	for {
+		fmt.Fprintf(p.out, "? %s [%s] ", prompt, hint)
+		answer, err := p.readLine()
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		fmt.Fprintln(p.out, "  Please answer y or n.")
+	}
+}
+
+// Text asks for a line of text. If validate is set, the answer (or the
+// default) is checked and the question repeated until it passes.
+func (p *Prompter) Text(prompt, def string, validate func(string) error) (string, error) {
+	for {
+		if def != "" {
+			fmt.Fprintf(p.out, "? %s (%s) ", prompt, def)
+		} else {
+			fmt.Fprintf(p.out, "? %s ", prompt)
+		}
+		answer, err := p.readLine()
+		if err != nil {
+			return "", err
+		}
+		if answer == "" {
+			answer = def
+		}
+		if validate != nil {
+			if err := validate(answer); err != nil {
+				fmt.Fprintf(p.out, "  %v\n", err)
+				continue
+			}
+		}
+		return answer, nil
+	}
+}
+
+// Select asks the user to pick one of options, by number or by name, and
+// returns its index. def is the index chosen by an empty answer.
+func (p *Prompter) Select(prompt string, options []string, def int) (int, error) {
+	for {
+		fmt.Fprintf(p.out, "? %s\n", prompt)
+		for i, o := range options {
+			marker := " "
+			if i == def {
+				marker = ">"
+			}
+			fmt.Fprintf(p.out, "  %s %d) %s\n", marker, i+1, o)
+		}
+		fmt.Fprintf(p.out, "  Choose 1-%d (%d): ", len(options), def+1)
+		answer, err := p.readLine()
+		if err != nil {
+			return 0, err
+		}
+		if answer == "" {
+			return def, nil
+		}
+		if i, ok := choice(answer, options); ok {
+			return i, nil
+		}
+		fmt.Fprintf(p.out, "  %q is not one of the options.\n", answer)
+	}
+}
+
+// MultiSelect asks the user to pick any number of options, as a
+// comma-separated list of numbers or names, and returns their indexes in
+// order. defaults are the indexes chosen by an empty answer; "none" chooses
+// nothing.
+func (p *Prompter) MultiSelect(prompt string, options []string, defaults []int) ([]int, error) {
+	for {
+		fmt.Fprintf(p.out, "? %s\n", prompt)
+		for i, o := range options {
+			marker := "[ ]"
+			if slices.Contains(defaults, i) {
+				marker = "[x]"
+			}
+			fmt.Fprintf(p.out, "  %s %d) %s\n", marker, i+1, o)
+		}
+		fmt.Fprint(p.out, "  Choose a comma-separated list, or none (defaults marked x): ")
+		answer, err := p.readLine()
+		if err != nil {
+			return nil, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return slices.Sorted(slices.Values(defaults)), nil
+		case "none":
+			return nil, nil
+		}
+
+		var picked []int
+		valid := true
+		for _, item := range strings.Split(answer, ",") {
+			i, ok := choice(strings.TrimSpace(item), options)
+			if !ok {
+				fmt.Fprintf(p.out, "  %q is not one of the options.\n", strings.TrimSpace(item))
+				valid = false
+				break
+			}
+			if !slices.Contains(picked, i) {
+				picked = append(picked, i)
+			}
+		}
+		if valid {
+			slices.Sort(picked)
+			return picked, nil
+		}
+	}
+}
+
+// choice resolves an answer given as a 1-based number or an option name.
+func choice(answer string, options []string) (int, bool) {
+	if n, err := strconv.Atoi(answer); err == nil {
+		return n - 1, n >= 1 && n <= len(options)
+	}
+	for i, o := range options {
+		if strings.EqualFold(answer, o) {
+			return i, true
+		}
+	}
+	return 0, false
+}
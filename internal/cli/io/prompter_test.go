@@ -0,0 +1,54 @@
+package io
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPrompter(t *testing.T) {
+	var out bytes.Buffer
+	p := NewPrompter(strings.NewReader("maybe\ny\n\n\nBad Name\nsvc\n7\npython\n\n1, 3\n"), &out)
+
+	if ok, err := p.Confirm("Continue?", false); err != nil || !ok {
+		t.Errorf("Confirm() = %t, %v; want true after re-asking", ok, err)
+	}
+	if ok, err := p.Confirm("Save?", true); err != nil || !ok {
+		t.Errorf("Confirm() = %t, %v; want the default", ok, err)
+	}
+
+	noSpaces := func(s string) error {
+		if strings.Contains(s, " ") {
+			return errors.New("no spaces allowed")
+		}
+		return nil
+	}
+	if name, err := p.Text("Name", "api", noSpaces); err != nil || name != "api" {
+		t.Errorf("Text() = %q, %v; want the default", name, err)
+	}
+	if name, err := p.Text("Name", "api", noSpaces); err != nil || name != "svc" {
+		t.Errorf("Text() = %q, %v; want svc after re-asking", name, err)
+	}
+	if !strings.Contains(out.String(), "no spaces allowed") {
+		t.Errorf("validation error not shown:\n%s", out.String())
+	}
+
+	languages := []string{"go", "typescript", "python"}
+	if i, err := p.Select("Language", languages, 0); err != nil || i != 2 {
+		t.Errorf("Select() = %d, %v; want 2 (by name, after an out-of-range number)", i, err)
+	}
+
+	components := []string{"actions", "docker", "flux"}
+	if picked, err := p.MultiSelect("Components", components, []int{1}); err != nil || !slices.Equal(picked, []int{1}) {
+		t.Errorf("MultiSelect() = %v, %v; want the defaults", picked, err)
+	}
+	if picked, err := p.MultiSelect("Components", components, []int{1}); err != nil || !slices.Equal(picked, []int{0, 2}) {
+		t.Errorf("MultiSelect() = %v, %v; want [0 2]", picked, err)
+	}
+
+	if _, err := p.Confirm("More?", false); !errors.Is(err, ErrNoInput) {
+		t.Errorf("Confirm() at end of input = %v, want ErrNoInput", err)
+	}
+}
@@ -0,0 +1,231 @@
+// Package config loads the platform-mcp server settings. Settings come from,
+// in increasing order of precedence, built-in defaults, a YAML file and
+// PLATFORM_MCP_* environment variables; command-line flags are applied on top
+// by the caller.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/platform.mcp/internal/logging"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix prefixes every environment variable read by ApplyEnv.
+const EnvPrefix = "PLATFORM_MCP_"
+
+// EnvConfig names the environment variable holding the config file path.
+const EnvConfig = EnvPrefix + "CONFIG"
+
+// Server holds the server settings.
+type Server struct {
+	// Transport is stdio or http.
+	Transport string `yaml:"transport"`
+	// Addr is the listen address of the http transport.
+	Addr string `yaml:"addr"`
+	// Stateless disables session tracking for the http transport.
+	Stateless bool `yaml:"stateless"`
+	// SessionTimeout closes idle http sessions; 0 disables it.
+	SessionTimeout time.Duration `yaml:"session_timeout"`
+	// LogLevel is the minimum level of logs written to stderr.
+	LogLevel string `yaml:"log_level"`
+	// Templates are external template directories, searched in order before
+	// the embedded templates. Earlier directories override later ones.
+	Templates []string `yaml:"templates"`
+	// ReloadInterval is how often the template directories are checked for
+	// changes; 0 disables reloading.
+	ReloadInterval time.Duration `yaml:"reload_interval"`
+	// Tools lists the tools to register. When empty, every tool that is not
+	// opt-in is registered.
+	Tools []string `yaml:"tools"`
+	// WorkspaceRoot confines the tools that act on the client's MCP roots to
+	// a server directory. Over http those tools only work when it is set.
+	WorkspaceRoot string `yaml:"workspace_root"`
+	// Defaults fill the Config fields a tool call leaves empty.
+	Defaults Defaults `yaml:"defaults"`
+	// Auth configures authentication for the http transport.
+	Auth Auth `yaml:"auth"`
+}
+
+// Defaults are default scaffold.Config values.
+type Defaults struct {
+	Org          string `yaml:"org"`
+	Registry     string `yaml:"registry"`
+	Branch       string `yaml:"branch"`
+	WorkflowType string `yaml:"workflow_type"`
+}
+
+// Config returns the defaults as a scaffold configuration.
+func (d Defaults) Config() scaffold.Config {
+	return scaffold.Config{Org: d.Org, Registry: d.Registry, Branch: d.Branch, WorkflowType: d.WorkflowType}
+}
+
+// Auth configures authentication for the http transport.
+type Auth struct {
+	// Mode is none, tokens or jwt.
+	Mode       string `yaml:"mode"`
+	TokensFile string `yaml:"tokens_file"`
+	JWKSFile   string `yaml:"jwks_file"`
+	Issuer     string `yaml:"issuer"`
+	Audience   string `yaml:"audience"`
+	PolicyFile string `yaml:"policy_file"`
+}
+
+// Default returns the built-in settings.
+func Default() Server {
+	return Server{
+		Transport:      "stdio",
+		Addr:           ":8080",
+		SessionTimeout: 30 * time.Minute,
+		LogLevel:       "info",
+		ReloadInterval: 2 * time.Second,
+		Auth:           Auth{Mode: "none"},
+	}
+}
+
+// Load reads the YAML file at path over s. Keys that are absent keep their
+// current value; unknown keys are an error.
+func (s *Server) Load(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	dec.KnownFields(true)
+	if err := dec.Decode(s); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to unmarshal config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// ApplyEnv overrides s with the PLATFORM_MCP_* variables returned by lookup,
+// typically os.LookupEnv. List variables are comma-separated.
+func (s *Server) ApplyEnv(lookup func(string) (string, bool)) error {
+	var errs []error
+	str := func(name string, dst *string) {
+		if v, ok := lookup(EnvPrefix + name); ok {
+			*dst = v
+		}
+	}
+	list := func(name string, dst *[]string) {
+		if v, ok := lookup(EnvPrefix + name); ok {
+			*dst = SplitList(v)
+		}
+	}
+
+	str("TRANSPORT", &s.Transport)
+	str("ADDR", &s.Addr)
+	if v, ok := lookup(EnvPrefix + "STATELESS"); ok {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%sSTATELESS: %w", EnvPrefix, err))
+		}
+		s.Stateless = b
+	}
+	duration := func(name string, dst *time.Duration) {
+		if v, ok := lookup(EnvPrefix + name); ok {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s%s: %w", EnvPrefix, name, err))
+			}
+			*dst = d
+		}
+	}
+	duration("SESSION_TIMEOUT", &s.SessionTimeout)
+	duration("RELOAD_INTERVAL", &s.ReloadInterval)
+	str("LOG_LEVEL", &s.LogLevel)
+	list("TEMPLATES", &s.Templates)
+	list("TOOLS", &s.Tools)
+	str("WORKSPACE_ROOT", &s.WorkspaceRoot)
+	str("DEFAULT_ORG", &s.Defaults.Org)
+	str("DEFAULT_REGISTRY", &s.Defaults.Registry)
+	str("DEFAULT_BRANCH", &s.Defaults.Branch)
+	str("DEFAULT_WORKFLOW_TYPE", &s.Defaults.WorkflowType)
+	str("AUTH", &s.Auth.Mode)
+	str("AUTH_TOKENS_FILE", &s.Auth.TokensFile)
+	str("AUTH_JWKS_FILE", &s.Auth.JWKSFile)
+	str("AUTH_ISSUER", &s.Auth.Issuer)
+	str("AUTH_AUDIENCE", &s.Auth.Audience)
+	str("AUTH_POLICY_FILE", &s.Auth.PolicyFile)
+	return errors.Join(errs...)
+}
+
+// SplitList splits a comma-separated list, dropping empty items.
+func SplitList(v string) []string {
+	var out []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// Validate reports every invalid setting. knownTools are the names of the
+// tools the server can register.
+func (s *Server) Validate(knownTools []string) error {
+	var errs []error
+	if s.Transport != "stdio" && s.Transport != "http" {
+		errs = append(errs, fmt.Errorf("unsupported transport %q (expected stdio or http)", s.Transport))
+	}
+	if s.Transport == "http" && s.Addr == "" {
+		errs = append(errs, errors.New("addr is required with the http transport"))
+	}
+	if s.SessionTimeout < 0 {
+		errs = append(errs, fmt.Errorf("session_timeout must not be negative, got %s", s.SessionTimeout))
+	}
+	if s.ReloadInterval < 0 {
+		errs = append(errs, fmt.Errorf("reload_interval must not be negative, got %s", s.ReloadInterval))
+	}
+	if _, err := logging.ParseLevel(s.LogLevel); err != nil {
+		errs = append(errs, err)
+	}
+	for _, dir := range s.Templates {
+		if info, err := os.Stat(dir); err != nil {
+			errs = append(errs, fmt.Errorf("template directory: %w", err))
+		} else if !info.IsDir() {
+			errs = append(errs, fmt.Errorf("template directory %s is not a directory", dir))
+		}
+	}
+	if s.WorkspaceRoot != "" {
+		if info, err := os.Stat(s.WorkspaceRoot); err != nil {
+			errs = append(errs, fmt.Errorf("workspace root: %w", err))
+		} else if !info.IsDir() {
+			errs = append(errs, fmt.Errorf("workspace root %s is not a directory", s.WorkspaceRoot))
+		}
+	}
+	for _, name := range s.Tools {
+		if !slices.Contains(knownTools, name) {
+			errs = append(errs, fmt.Errorf("unknown tool %q (expected one of %s)", name, strings.Join(knownTools, ", ")))
+		}
+	}
+	// The project name is supplied per call; a placeholder lets the remaining
+	// fields go through the same checks as a tool call.
+	defaults := s.Defaults.Config()
+	defaults.ProjectName = "defaults"
+	if err := scaffold.ValidateConfig(defaults); err != nil {
+		errs = append(errs, fmt.Errorf("defaults: %w", err))
+	}
+	switch s.Auth.Mode {
+	case "none", "tokens", "jwt":
+	default:
+		errs = append(errs, fmt.Errorf("unsupported auth mode %q (expected none, tokens or jwt)", s.Auth.Mode))
+	}
+	if s.Auth.Mode != "none" && s.Transport != "http" {
+		errs = append(errs, fmt.Errorf("auth mode %q requires the http transport", s.Auth.Mode))
+	}
+	// Without authentication every caller is allowed, whatever the policy says.
+	if s.Auth.Mode == "none" && s.Auth.PolicyFile != "" {
+		errs = append(errs, errors.New("auth policy_file requires auth mode tokens or jwt; with none, every caller is allowed"))
+	}
+	return errors.Join(errs...)
+}
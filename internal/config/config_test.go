@@ -0,0 +1,145 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var tools = []string{"generate", "preview", "apply"}
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "platform-mcp.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDefaultIsValid(t *testing.T) {
+	s := Default()
+	if err := s.Validate(tools); err != nil {
+		t.Fatalf("Default().Validate() = %v", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	templates := t.TempDir()
+	s := Default()
+	err := s.Load(writeFile(t, `
+transport: http
+session_timeout: 5m
+templates: [`+templates+`]
+tools: [generate, apply]
+defaults:
+  org: acme
+  registry: ghcr.io
+  branch: main
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Transport != "http" || s.Addr != ":8080" || s.SessionTimeout != 5*time.Minute {
+		t.Errorf("transport settings = %q %q %s", s.Transport, s.Addr, s.SessionTimeout)
+	}
+	if len(s.Tools) != 2 || s.Defaults.Org != "acme" || s.Defaults.Registry != "ghcr.io" || s.Defaults.Branch != "main" {
+		t.Errorf("loaded %+v", s)
+	}
+	if err := s.Validate(tools); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+}
+
+func TestLoad_UnknownKey(t *testing.T) {
+	s := Default()
+	if err := s.Load(writeFile(t, "transprot: http\n")); err == nil {
+		t.Fatal("expected an error for an unknown key")
+	}
+}
+
+func TestLoad_Empty(t *testing.T) {
+	s := Default()
+	if err := s.Load(writeFile(t, "")); err != nil {
+		t.Fatalf("Load(empty) = %v", err)
+	}
+	if s.Transport != "stdio" {
+		t.Errorf("transport = %q, want the default", s.Transport)
+	}
+}
+
+func TestApplyEnv(t *testing.T) {
+	env := map[string]string{
+		"PLATFORM_MCP_TRANSPORT":       "http",
+		"PLATFORM_MCP_STATELESS":       "true",
+		"PLATFORM_MCP_SESSION_TIMEOUT": "1h",
+		"PLATFORM_MCP_RELOAD_INTERVAL": "0s",
+		"PLATFORM_MCP_TOOLS":           "generate, preview",
+		"PLATFORM_MCP_DEFAULT_ORG":     "acme",
+		"PLATFORM_MCP_AUTH_ISSUER":     "https://issuer.example.com",
+	}
+	lookup := func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+
+	s := Default()
+	s.LogLevel = "debug"
+	if err := s.ApplyEnv(lookup); err != nil {
+		t.Fatal(err)
+	}
+	if s.Transport != "http" || !s.Stateless || s.SessionTimeout != time.Hour {
+		t.Errorf("transport settings = %q %t %s", s.Transport, s.Stateless, s.SessionTimeout)
+	}
+	if s.ReloadInterval != 0 {
+		t.Errorf("reload interval = %s, want 0", s.ReloadInterval)
+	}
+	if strings.Join(s.Tools, ",") != "generate,preview" {
+		t.Errorf("tools = %q", s.Tools)
+	}
+	if s.Defaults.Org != "acme" || s.Auth.Issuer != "https://issuer.example.com" {
+		t.Errorf("defaults/auth = %+v %+v", s.Defaults, s.Auth)
+	}
+	if s.LogLevel != "debug" {
+		t.Errorf("unset variable overrode log level: %q", s.LogLevel)
+	}
+
+	env = map[string]string{"PLATFORM_MCP_STATELESS": "maybe", "PLATFORM_MCP_SESSION_TIMEOUT": "soon"}
+	err := s.ApplyEnv(lookup)
+	if err == nil || !strings.Contains(err.Error(), "STATELESS") || !strings.Contains(err.Error(), "SESSION_TIMEOUT") {
+		t.Errorf("ApplyEnv() = %v, want errors for both variables", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Server)
+		want   string
+	}{
+		{"transport", func(s *Server) { s.Transport = "grpc" }, "unsupported transport"},
+		{"log level", func(s *Server) { s.LogLevel = "loud" }, "invalid log level"},
+		{"template dir", func(s *Server) { s.Templates = []string{filepath.Join(t.TempDir(), "missing")} }, "template directory"},
+		{"workspace root", func(s *Server) { s.WorkspaceRoot = filepath.Join(t.TempDir(), "missing") }, "workspace root"},
+		{"tool", func(s *Server) { s.Tools = []string{"deploy"} }, `unknown tool "deploy"`},
+		{"registry", func(s *Server) { s.Defaults.Registry = "https://ghcr.io" }, "defaults: registry"},
+		{"workflow type", func(s *Server) { s.Defaults.WorkflowType = "cobol" }, "defaults: unsupported workflow type"},
+		{"auth mode", func(s *Server) { s.Transport = "http"; s.Auth.Mode = "basic" }, "unsupported auth mode"},
+		{"auth over stdio", func(s *Server) { s.Auth.Mode = "tokens" }, "requires the http transport"},
+		{"policy without auth", func(s *Server) { s.Transport = "http"; s.Auth.PolicyFile = "policy.yaml" }, "policy_file requires auth mode"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Default()
+			tt.modify(&s)
+			err := s.Validate(tools)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
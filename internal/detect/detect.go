@@ -0,0 +1,294 @@
+// Package detect inspects an existing repository and suggests the
+// scaffold.Config that matches it, with a note explaining every choice.
+package detect
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+)
+
+// Confidence rates how strongly the evidence supports a suggestion.
+type Confidence string
+
+const (
+	ConfidenceHigh   Confidence = "high"
+	ConfidenceMedium Confidence = "medium"
+	ConfidenceLow    Confidence = "low"
+)
+
+// Note explains the value suggested for one Config field.
+type Note struct {
+	Field      string     `json:"field"`
+	Value      any        `json:"value"`
+	Confidence Confidence `json:"confidence"`
+	Reason     string     `json:"reason"`
+}
+
+// Report is the result of analysing a directory.
+type Report struct {
+	Directory string          `json:"directory"`
+	Config    scaffold.Config `json:"config"`
+	Notes     []Note          `json:"notes"`
+}
+
+// Analyze inspects dir and suggests a Config for it. It only reads files.
+func Analyze(dir string) (*Report, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("failed to analyze %s: not a directory", dir)
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze %s: %w", dir, err)
+	}
+
+	a := &analyzer{dir: abs, report: &Report{Directory: abs, Config: scaffold.DefaultConfig()}}
+	a.detectLanguage()
+	a.detectProjectName()
+	a.detectDocker()
+	a.detectActions()
+	a.detectFlux()
+	return a.report, nil
+}
+
+// WriteText writes a human-readable summary of the report to w: one line per
+// suggested field, with its confidence and reason.
+func (r *Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Suggested configuration for %s:\n", r.Directory)
+	for _, n := range r.Notes {
+		fmt.Fprintf(tw, "  %s\t%v\t(%s)\t%s\n", n.Field, n.Value, n.Confidence, n.Reason)
+	}
+	return tw.Flush()
+}
+
+type analyzer struct {
+	dir    string
+	report *Report
+
+	// Manifest contents, read once by detectLanguage.
+	goMod       string
+	packageJSON map[string]any
+	pyproject   string
+}
+
+func (a *analyzer) note(field string, value any, confidence Confidence, reason string) {
+	a.report.Notes = append(a.report.Notes, Note{Field: field, Value: value, Confidence: confidence, Reason: reason})
+}
+
+func (a *analyzer) exists(rel string) bool {
+	_, err := os.Stat(filepath.Join(a.dir, rel))
+	return err == nil
+}
+
+func (a *analyzer) read(rel string) string {
+	data, err := os.ReadFile(filepath.Join(a.dir, rel))
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// detectLanguage picks the workflow type from the language manifests. When
+// several are present the first in Go, TypeScript, Node, Python order wins.
+func (a *analyzer) detectLanguage() {
+	a.goMod = a.read("go.mod")
+	a.pyproject = a.read("pyproject.toml")
+	if data := a.read("package.json"); data != "" {
+		_ = json.Unmarshal([]byte(data), &a.packageJSON)
+	}
+
+	type candidate struct {
+		workflowType string
+		confidence   Confidence
+		reason       string
+	}
+	var found []candidate
+	if a.goMod != "" {
+		found = append(found, candidate{"go", ConfidenceHigh, "go.mod found"})
+	}
+	switch {
+	case a.exists("tsconfig.json"):
+		found = append(found, candidate{"typescript", ConfidenceHigh, "tsconfig.json found"})
+	case a.packageJSON != nil && hasDependency(a.packageJSON, "typescript"):
+		found = append(found, candidate{"typescript", ConfidenceMedium, "package.json depends on typescript"})
+	case a.packageJSON != nil:
+		found = append(found, candidate{"node", ConfidenceMedium, "package.json found without TypeScript"})
+	}
+	switch {
+	case a.pyproject != "":
+		found = append(found, candidate{"python", ConfidenceHigh, "pyproject.toml found"})
+	case a.exists("requirements.txt"):
+		found = append(found, candidate{"python", ConfidenceHigh, "requirements.txt found"})
+	}
+
+	if len(found) == 0 {
+		a.note("workflow_type", a.report.Config.WorkflowType, ConfidenceLow,
+			"no go.mod, package.json, tsconfig.json, pyproject.toml or requirements.txt found; using the default")
+		return
+	}
+
+	best := found[0]
+	reason := best.reason
+	confidence := best.confidence
+	if len(found) > 1 {
+		var others []string
+		for _, c := range found[1:] {
+			others = append(others, fmt.Sprintf("%s (%s)", c.workflowType, c.reason))
+		}
+		reason += "; also found " + strings.Join(others, ", ") + ", so this may be a polyglot repository"
+		confidence = ConfidenceMedium
+	}
+	a.report.Config.WorkflowType = best.workflowType
+	a.note("workflow_type", best.workflowType, confidence, reason)
+}
+
+var (
+	goModuleLine = regexp.MustCompile(`(?m)^module\s+(\S+)`)
+	tomlName     = regexp.MustCompile(`^name\s*=\s*["']([^"']+)["']`)
+	invalidName  = regexp.MustCompile(`[^a-zA-Z0-9-]+`)
+	majorVersion = regexp.MustCompile(`^v\d+$`)
+)
+
+// detectProjectName takes the name from the first language manifest that
+// declares one, falling back to the directory name.
+func (a *analyzer) detectProjectName() {
+	name, reason, confidence := "", "", ConfidenceHigh
+	if m := goModuleLine.FindStringSubmatch(a.goMod); m != nil {
+		name, reason = lastElement(m[1]), fmt.Sprintf("last element of the Go module path %s", m[1])
+	}
+	if name == "" {
+		if n, ok := a.packageJSON["name"].(string); ok && n != "" {
+			name, reason = lastElement(n), fmt.Sprintf("package.json name %s", n)
+		}
+	}
+	if name == "" {
+		if n := pyprojectName(a.pyproject); n != "" {
+			name, reason = n, fmt.Sprintf("pyproject.toml name %s", n)
+		}
+	}
+	if name == "" {
+		name, reason, confidence = filepath.Base(a.dir), "directory name; no manifest declares a project name", ConfidenceLow
+	}
+
+	sanitized := strings.Trim(invalidName.ReplaceAllString(name, "-"), "-")
+	if sanitized != name {
+		reason += fmt.Sprintf(", with characters other than letters, digits and hyphens replaced (was %q)", name)
+		if confidence == ConfidenceHigh {
+			confidence = ConfidenceMedium
+		}
+	}
+	a.report.Config.ProjectName = sanitized
+	a.note("project_name", sanitized, confidence, reason)
+}
+
+func (a *analyzer) detectDocker() {
+	switch {
+	case a.exists("Dockerfile"):
+		a.setDocker(true, ConfidenceHigh, "Dockerfile found; generate skips it unless forced")
+	case a.exists("docker-compose.yml"), a.exists("docker-compose.yaml"), a.exists("compose.yaml"):
+		a.setDocker(true, ConfidenceMedium, "Compose file found but no Dockerfile at the root")
+	default:
+		a.setDocker(false, ConfidenceMedium, "no Dockerfile or Compose file found")
+	}
+}
+
+func (a *analyzer) setDocker(enabled bool, confidence Confidence, reason string) {
+	a.report.Config.WithDocker = enabled
+	a.report.Config.UseDocker = enabled
+	a.note("with_docker", enabled, confidence, reason)
+}
+
+func (a *analyzer) detectActions() {
+	workflows, _ := filepath.Glob(filepath.Join(a.dir, ".github", "workflows", "*.y*ml"))
+	a.report.Config.WithActions = true
+	if len(workflows) > 0 {
+		a.note("with_actions", true, ConfidenceHigh, fmt.Sprintf("%d workflow(s) found in .github/workflows", len(workflows)))
+		return
+	}
+	a.note("with_actions", true, ConfidenceMedium, "no GitHub Actions workflows found; CI is recommended")
+}
+
+// detectFlux looks for Flux resources below deploy/.
+func (a *analyzer) detectFlux() {
+	deploy := filepath.Join(a.dir, "deploy")
+	if info, err := os.Stat(deploy); err != nil || !info.IsDir() {
+		a.note("with_flux", false, ConfidenceMedium, "no deploy/ directory found")
+		return
+	}
+
+	flux := false
+	_ = filepath.WalkDir(deploy, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !(strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml")) {
+			return nil
+		}
+		if data, err := os.ReadFile(path); err == nil && strings.Contains(string(data), "fluxcd.io/") {
+			flux = true
+			return fs.SkipAll
+		}
+		return nil
+	})
+
+	a.report.Config.WithFlux = true
+	if flux {
+		a.note("with_flux", true, ConfidenceHigh, "deploy/ contains Flux resources")
+		return
+	}
+	a.note("with_flux", true, ConfidenceLow, "deploy/ exists but contains no Flux resources")
+}
+
+func hasDependency(pkg map[string]any, name string) bool {
+	for _, key := range []string{"dependencies", "devDependencies"} {
+		if deps, ok := pkg[key].(map[string]any); ok {
+			if _, ok := deps[name]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lastElement returns the last path element of a module path or scoped npm
+// package name, skipping a Go major version suffix.
+func lastElement(name string) string {
+	parts := strings.Split(strings.TrimSuffix(name, "/"), "/")
+	last := parts[len(parts)-1]
+	if len(parts) > 1 && majorVersion.MatchString(last) {
+		last = parts[len(parts)-2]
+	}
+	return last
+}
+
+// pyprojectName returns the name declared in the [project] or
+// [tool.poetry] table of a pyproject.toml.
+func pyprojectName(pyproject string) string {
+	scanner := bufio.NewScanner(strings.NewReader(pyproject))
+	table := ""
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			table = strings.Trim(line, "[] ")
+			continue
+		}
+		if table != "project" && table != "tool.poetry" {
+			continue
+		}
+		if m := tomlName.FindStringSubmatch(line); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,143 @@
+package detect
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func noteFor(t *testing.T, r *Report, field string) Note {
+	t.Helper()
+	for _, n := range r.Notes {
+		if n.Field == field {
+			return n
+		}
+	}
+	t.Fatalf("no note for %s in %+v", field, r.Notes)
+	return Note{}
+}
+
+func TestAnalyze_GoService(t *testing.T) {
+	dir := writeTree(t, map[string]string{
+		"go.mod":                       "module github.com/acme/billing-api/v2\n\ngo 1.25\n",
+		"Dockerfile":                   "FROM scratch\n",
+		".github/workflows/ci.yaml":    "name: ci\n",
+		"deploy/kustomization.yaml":    "apiVersion: kustomize.toolkit.fluxcd.io/v1\nkind: Kustomization\n",
+		"deploy/nested/release.yaml":   "kind: HelmRelease\n",
+		"internal/ignored/package.txt": "",
+	})
+
+	r, err := Analyze(dir)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	cfg := r.Config
+	if cfg.ProjectName != "billing-api" || cfg.WorkflowType != "go" || !cfg.WithDocker || !cfg.WithActions || !cfg.WithFlux {
+		t.Errorf("unexpected config %+v", cfg)
+	}
+	for _, field := range []string{"project_name", "workflow_type", "with_docker", "with_actions", "with_flux"} {
+		if n := noteFor(t, r, field); n.Confidence != ConfidenceHigh {
+			t.Errorf("%s confidence = %s (%s)", field, n.Confidence, n.Reason)
+		}
+	}
+}
+
+func TestAnalyze_Languages(t *testing.T) {
+	tests := []struct {
+		name         string
+		files        map[string]string
+		wantType     string
+		wantName     string
+		wantConfName Confidence
+	}{
+		{
+			name:     "typescript",
+			files:    map[string]string{"package.json": `{"name": "@acme/web_ui"}`, "tsconfig.json": "{}"},
+			wantType: "typescript", wantName: "web-ui", wantConfName: ConfidenceMedium,
+		},
+		{
+			name:     "typescript dependency",
+			files:    map[string]string{"package.json": `{"name": "web", "devDependencies": {"typescript": "^5"}}`},
+			wantType: "typescript", wantName: "web", wantConfName: ConfidenceHigh,
+		},
+		{
+			name:     "node",
+			files:    map[string]string{"package.json": `{"name": "server"}`},
+			wantType: "node", wantName: "server", wantConfName: ConfidenceHigh,
+		},
+		{
+			name:     "pyproject",
+			files:    map[string]string{"pyproject.toml": "[build-system]\nrequires = []\n\n[project]\nname = \"ml-jobs\"\n"},
+			wantType: "python", wantName: "ml-jobs", wantConfName: ConfidenceHigh,
+		},
+		{
+			name:     "requirements",
+			files:    map[string]string{"requirements.txt": "requests\n"},
+			wantType: "python", wantConfName: ConfidenceLow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Analyze(writeTree(t, tt.files))
+			if err != nil {
+				t.Fatalf("Analyze failed: %v", err)
+			}
+			if r.Config.WorkflowType != tt.wantType {
+				t.Errorf("workflow type = %s, want %s", r.Config.WorkflowType, tt.wantType)
+			}
+			if tt.wantName != "" && r.Config.ProjectName != tt.wantName {
+				t.Errorf("project name = %s, want %s", r.Config.ProjectName, tt.wantName)
+			}
+			if n := noteFor(t, r, "project_name"); n.Confidence != tt.wantConfName {
+				t.Errorf("project name confidence = %s (%s)", n.Confidence, n.Reason)
+			}
+		})
+	}
+}
+
+func TestAnalyze_EmptyAndPolyglot(t *testing.T) {
+	r, err := Analyze(t.TempDir())
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if r.Config.WorkflowType != "go" || r.Config.WithDocker || r.Config.WithFlux {
+		t.Errorf("unexpected config for empty dir %+v", r.Config)
+	}
+	if n := noteFor(t, r, "workflow_type"); n.Confidence != ConfidenceLow {
+		t.Errorf("workflow type confidence = %s", n.Confidence)
+	}
+
+	r, err = Analyze(writeTree(t, map[string]string{"go.mod": "module tool\n", "requirements.txt": ""}))
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if n := noteFor(t, r, "workflow_type"); n.Value != "go" || n.Confidence != ConfidenceMedium {
+		t.Errorf("unexpected polyglot note %+v", n)
+	}
+}
+
+func TestAnalyze_NotDirectory(t *testing.T) {
+	dir := writeTree(t, map[string]string{"file": ""})
+	if _, err := Analyze(filepath.Join(dir, "file")); err == nil {
+		t.Error("expected an error for a file")
+	}
+	if _, err := Analyze(filepath.Join(dir, "missing")); err == nil {
+		t.Error("expected an error for a missing path")
+	}
+}
@@ -0,0 +1,107 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{
+			name: "Equal",
+			a:    "a\nb\n",
+			b:    "a\nb\n",
+			want: "",
+		},
+		{
+			name: "New File",
+			a:    "",
+			b:    "a\nb\n",
+			want: "--- a\n+++ b\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name: "Single Change",
+			a:    "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			b:    "1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+			want: "--- a\n+++ b\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			name: "Separate Hunks",
+			a:    "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			b:    "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve\n",
+			want: "--- a\n+++ b\n@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+twelve\n",
+		},
+		{
+			name: "Missing Trailing Newline",
+			a:    "a\nb",
+			b:    "a\nb\n",
+			want: "--- a\n+++ b\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Unified("a", "b", tt.a, tt.b)
+			if got != tt.want {
+				t.Errorf("Unified() mismatch\ngot:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnified_RoundTripLineCounts(t *testing.T) {
+	a := strings.Repeat("same\n", 50) + "old\n" + strings.Repeat("same\n", 50)
+	b := strings.Repeat("same\n", 50) + "new\nextra\n" + strings.Repeat("same\n", 50)
+
+	got := Unified("a", "b", a, b)
+	if !strings.Contains(got, "@@ -48,7 +48,8 @@") {
+		t.Errorf("unexpected hunk header in:\n%s", got)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	base := "a\nb\nc\nd\ne\n"
+	tests := []struct {
+		name          string
+		ours, theirs  string
+		want          string
+		wantConflicts int
+	}{
+		{"Unchanged", base, base, base, 0},
+		{"Ours Only", "a\nB\nc\nd\ne\n", base, "a\nB\nc\nd\ne\n", 0},
+		{"Theirs Only", base, "a\nb\nc\nD\ne\n", "a\nb\nc\nD\ne\n", 0},
+		{"Both Separate", "a\nB\nc\nd\ne\n", "a\nb\nc\nD\ne\n", "a\nB\nc\nD\ne\n", 0},
+		{"Both Same", "a\nB\nc\nd\ne\n", "a\nB\nc\nd\ne\n", "a\nB\nc\nd\ne\n", 0},
+		{"Insert And Delete", "start\na\nb\nc\nd\ne\n", "a\nb\nc\nd\n", "start\na\nb\nc\nd\n", 0},
+		{
+			"Conflict", "a\nb\nours\nd\ne\n", "a\nb\ntheirs\nd\ne\n",
+			"a\nb\n<<<<<<< local\nours\n||||||| base\nc\n=======\ntheirs\n>>>>>>> template\nd\ne\n", 1,
+		},
+		{
+			"Conflict Without Trailing Newline", "a\nb\nc\nd\nours", "a\nb\nc\nd\ntheirs",
+			"a\nb\nc\nd\n<<<<<<< local\nours\n||||||| base\ne\n=======\ntheirs\n>>>>>>> template\n", 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, conflicts := Merge(base, tt.ours, tt.theirs, "local", "template")
+			if got != tt.want || conflicts != tt.wantConflicts {
+				t.Errorf("Merge() = %q, %d conflicts; want %q, %d", got, conflicts, tt.want, tt.wantConflicts)
+			}
+		})
+	}
+}
+
+func TestMerge_NoBase(t *testing.T) {
+	if got, conflicts := Merge("", "", "new\n", "local", "template"); got != "new\n" || conflicts != 0 {
+		t.Errorf("Merge(new file) = %q, %d", got, conflicts)
+	}
+	if got, conflicts := Merge("", "x\n", "y\n", "local", "template"); conflicts != 1 || !strings.Contains(got, "<<<<<<< local\nx\n") {
+		t.Errorf("Merge(no base) = %q, %d", got, conflicts)
+	}
+}
@@ -0,0 +1,117 @@
+package diff
+
+import (
+	"slices"
+	"strings"
+)
+
+// Conflict markers written by Merge, in the diff3 style used by git.
+const (
+	MarkerOurs   = "<<<<<<<"
+	MarkerBase   = "|||||||"
+	MarkerSplit  = "======="
+	MarkerTheirs = ">>>>>>>"
+)
+
+// Merge performs a line-based three-way merge of two descendants of base:
+// changes made on only one side are applied, and regions changed differently
+// on both sides are written with conflict markers labelled with the given
+// names. It returns the merged text and the number of conflicts.
+func Merge(base, ours, theirs, oursName, theirsName string) (string, int) {
+	o, a, b := rawLines(base), rawLines(ours), rawLines(theirs)
+	matchA, matchB := matches(o, a), matches(o, b)
+
+	var sb strings.Builder
+	conflicts := 0
+	i, x, y := 0, 0, 0
+	for {
+		// Copy the lines unchanged on both sides.
+		for i < len(o) && matchA[i] == x && matchB[i] == y {
+			sb.WriteString(o[i])
+			i, x, y = i+1, x+1, y+1
+		}
+		if i == len(o) && x == len(a) && y == len(b) {
+			break
+		}
+
+		// The changed region ends at the next base line kept on both sides.
+		ni, nx, ny := len(o), len(a), len(b)
+		for j := i; j < len(o); j++ {
+			if matchA[j] >= 0 && matchB[j] >= 0 {
+				ni, nx, ny = j, matchA[j], matchB[j]
+				break
+			}
+		}
+		co, ca, cb := o[i:ni], a[x:nx], b[y:ny]
+		switch {
+		case slices.Equal(ca, co):
+			writeLines(&sb, cb)
+		case slices.Equal(cb, co), slices.Equal(ca, cb):
+			writeLines(&sb, ca)
+		default:
+			conflicts++
+			sb.WriteString(MarkerOurs + " " + oursName + "\n")
+			writeSide(&sb, ca)
+			sb.WriteString(MarkerBase + " base\n")
+			writeSide(&sb, co)
+			sb.WriteString(MarkerSplit + "\n")
+			writeSide(&sb, cb)
+			sb.WriteString(MarkerTheirs + " " + theirsName + "\n")
+		}
+		i, x, y = ni, nx, ny
+	}
+	return sb.String(), conflicts
+}
+
+// rawLines splits s into lines, each keeping its newline.
+func rawLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// matches returns, for each line of a, the index of the line of b it is kept
+// as in the shortest edit script turning a into b, or -1 if it is deleted.
+func matches(a, b []string) []int {
+	match := make([]int, len(a))
+	if len(a) == 0 || len(b) == 0 {
+		for i := range match {
+			match[i] = -1
+		}
+		return match
+	}
+	i, j := 0, 0
+	for _, o := range edits(a, b) {
+		switch o.kind {
+		case opEqual:
+			match[i] = j
+			i, j = i+1, j+1
+		case opDelete:
+			match[i] = -1
+			i++
+		case opInsert:
+			j++
+		}
+	}
+	return match
+}
+
+func writeLines(sb *strings.Builder, lines []string) {
+	for _, l := range lines {
+		sb.WriteString(l)
+	}
+}
+
+// writeSide writes one side of a conflict, ending it with a newline so that
+// the next marker starts a line of its own.
+func writeSide(sb *strings.Builder, lines []string) {
+	writeLines(sb, lines)
+	if n := len(lines); n > 0 && !strings.HasSuffix(lines[n-1], "\n") {
+		sb.WriteString("\n")
+	}
+}
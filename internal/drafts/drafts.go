@@ -0,0 +1,201 @@
+// Package drafts writes the prose that accompanies a generated project: a
+// README, a pull request description and a CODEOWNERS suggestion. Drafts are
+// written by a language model when one is available and rendered from
+// deterministic templates otherwise. The prompts are fixed by the server;
+// callers only contribute the validated scaffold configuration.
+package drafts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/modelcontextprotocol/platform.mcp/internal/logging"
+	"github.com/modelcontextprotocol/platform.mcp/internal/templates"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+)
+
+// Paths of the drafted files.
+const (
+	ReadmePath        = "README.md"
+	PRDescriptionPath = ".platform/PR_DESCRIPTION.md"
+	CodeownersPath    = ".github/CODEOWNERS"
+)
+
+// maxDraftBytes caps the size of a sampled draft.
+const maxDraftBytes = 8 << 10
+
+// Sampler asks a language model to complete a prompt.
+type Sampler interface {
+	Sample(ctx context.Context, systemPrompt, prompt string, maxTokens int64) (string, error)
+}
+
+const systemPrompt = "You write concise documentation for newly scaffolded software projects. " +
+	"Reply with the requested file content only, without surrounding code fences or commentary."
+
+// draft describes one drafted file.
+type draft struct {
+	path      string
+	template  string // fallback template in the templates package
+	maxTokens int64
+	prompt    string // instructions; the project summary is appended
+	valid     func(string) bool
+}
+
+var drafts = []draft{
+	{
+		path:      ReadmePath,
+		template:  "draft-readme.md.tmpl",
+		maxTokens: 800,
+		prompt:    "Write a short Markdown README for the project below: a one-paragraph introduction, then how to build, test and deploy it using the generated files. Keep it under 60 lines.",
+		valid:     nonEmpty,
+	},
+	{
+		path:      PRDescriptionPath,
+		template:  "draft-pr-description.md.tmpl",
+		maxTokens: 500,
+		prompt:    "Write a Markdown pull request description that adds the generated files below to the repository. Summarize what each file does and end with a short review checklist.",
+		valid:     nonEmpty,
+	},
+	{
+		path:      CodeownersPath,
+		template:  "draft-codeowners.tmpl",
+		maxTokens: 200,
+		prompt:    "Suggest a GitHub CODEOWNERS file for the generated files below. Use placeholder teams of the form @your-org/team-name. Output only CODEOWNERS lines and # comments.",
+		valid:     validCodeowners,
+	},
+}
+
+// Generate drafts the README, PR description and CODEOWNERS files for the
+// project described by cfg and files. If sampler is nil, or sampling a draft
+// fails or returns unusable content, that draft is rendered from its
+// fallback template instead.
+func Generate(ctx context.Context, sampler Sampler, cfg scaffold.Config, files []scaffold.File) ([]scaffold.File, error) {
+	logger := logging.FromContext(ctx)
+	summary := summarize(cfg, files)
+
+	out := make([]scaffold.File, 0, len(drafts))
+	for _, d := range drafts {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("drafting cancelled: %w", err)
+		}
+
+		content, err := sample(ctx, sampler, d, summary)
+		if err != nil {
+			if sampler != nil {
+				logger.Warn("sampling failed, using fallback draft", "path", d.path, "error", err)
+			}
+			content, err = fallback(d, cfg, files)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			logger.Debug("draft sampled", "path", d.path, "bytes", len(content))
+		}
+		out = append(out, scaffold.File{Path: d.path, Content: content, Mode: 0644})
+	}
+	return out, nil
+}
+
+var errNoSampler = errors.New("sampling is not available")
+
+func sample(ctx context.Context, sampler Sampler, d draft, summary string) (string, error) {
+	if sampler == nil {
+		return "", errNoSampler
+	}
+	text, err := sampler.Sample(ctx, systemPrompt, d.prompt+"\n\n"+summary, d.maxTokens)
+	if err != nil {
+		return "", err
+	}
+	text = clean(text)
+	switch {
+	case len(text) > maxDraftBytes:
+		return "", fmt.Errorf("draft is %d bytes, more than the %d allowed", len(text), maxDraftBytes)
+	case !d.valid(text):
+		return "", errors.New("draft is not valid")
+	}
+	return text, nil
+}
+
+func fallback(d draft, cfg scaffold.Config, files []scaffold.File) (string, error) {
+	tmpl, err := templates.Load(d.template)
+	if err != nil {
+		return "", err
+	}
+	content, err := templates.Render(tmpl, templateData{Config: cfg, Files: paths(files)})
+	if err != nil {
+		return "", fmt.Errorf("failed to render fallback for %s: %w", d.path, err)
+	}
+	return content, nil
+}
+
+// templateData is the data passed to fallback templates.
+type templateData struct {
+	scaffold.Config
+	Files []string
+}
+
+// summarize describes the project for the prompts. Only validated
+// configuration values and generated paths are included.
+func summarize(cfg scaffold.Config, files []scaffold.File) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Project name: %s\n", cfg.ProjectName)
+	if cfg.WorkflowType != "" {
+		fmt.Fprintf(&sb, "Language: %s\n", cfg.WorkflowType)
+	}
+	fmt.Fprintf(&sb, "GitHub Actions: %t\nDocker: %t\nFlux CD: %t\n", cfg.WithActions, cfg.WithDocker || cfg.UseDocker, cfg.WithFlux)
+	sb.WriteString("Generated files:\n")
+	for _, f := range files {
+		fmt.Fprintf(&sb, "- %s (%d lines)\n", f.Path, strings.Count(f.Content, "\n"))
+	}
+	return sb.String()
+}
+
+func paths(files []scaffold.File) []string {
+	out := make([]string, len(files))
+	for i, f := range files {
+		out[i] = f.Path
+	}
+	return out
+}
+
+var fence = regexp.MustCompile("(?s)^```[a-zA-Z]*\n(.*?)\n?```$")
+
+// clean trims whitespace and a fence wrapped around the whole reply, and
+// makes sure the text ends with a newline.
+func clean(text string) string {
+	text = strings.TrimSpace(text)
+	if m := fence.FindStringSubmatch(text); m != nil {
+		text = strings.TrimSpace(m[1])
+	}
+	if text == "" {
+		return ""
+	}
+	return text + "\n"
+}
+
+func nonEmpty(text string) bool {
+	return text != ""
+}
+
+// codeownersLine matches a CODEOWNERS rule: a pattern followed by owners.
+var codeownersLine = regexp.MustCompile(`^\S+(\s+(@[\w./-]+|[\w.+-]+@[\w.-]+))+$`)
+
+// validCodeowners reports whether every non-comment line is a rule.
+func validCodeowners(text string) bool {
+	if text == "" {
+		return false
+	}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !codeownersLine.MatchString(line) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,143 @@
+package drafts
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+)
+
+type fakeSampler struct {
+	replies map[string]string // keyed by a substring of the prompt
+	err     error
+	prompts []string
+}
+
+func (s *fakeSampler) Sample(_ context.Context, system, prompt string, maxTokens int64) (string, error) {
+	s.prompts = append(s.prompts, prompt)
+	if s.err != nil {
+		return "", s.err
+	}
+	for key, reply := range s.replies {
+		if strings.Contains(prompt, key) {
+			return reply, nil
+		}
+	}
+	return "", nil
+}
+
+var (
+	testConfig = scaffold.Config{ProjectName: "orders", WorkflowType: "go", WithActions: true, WithDocker: true}
+	testFiles  = []scaffold.File{
+		{Path: ".github/workflows/ci.yaml", Content: "name: ci\n"},
+		{Path: "Dockerfile", Content: "FROM scratch\n"},
+	}
+)
+
+func byPath(files []scaffold.File) map[string]string {
+	m := make(map[string]string)
+	for _, f := range files {
+		m[f.Path] = f.Content
+	}
+	return m
+}
+
+func TestGenerate_Fallback(t *testing.T) {
+	files, err := Generate(context.Background(), nil, testConfig, testFiles)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	got := byPath(files)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 drafts, got %v", got)
+	}
+	if !strings.HasPrefix(got[ReadmePath], "# orders\n") || !strings.Contains(got[ReadmePath], "docker build -t orders .") {
+		t.Errorf("unexpected README:\n%s", got[ReadmePath])
+	}
+	if !strings.Contains(got[PRDescriptionPath], "- `Dockerfile`") {
+		t.Errorf("PR description does not list the files:\n%s", got[PRDescriptionPath])
+	}
+	if !validCodeowners(got[CodeownersPath]) || !strings.Contains(got[CodeownersPath], "@your-org/orders-maintainers") {
+		t.Errorf("unexpected CODEOWNERS:\n%s", got[CodeownersPath])
+	}
+
+	// The fallback is deterministic.
+	again, _ := Generate(context.Background(), nil, testConfig, testFiles)
+	if len(again) != len(files) || again[0] != files[0] {
+		t.Error("fallback drafts differ between runs")
+	}
+}
+
+func TestGenerate_Sampled(t *testing.T) {
+	sampler := &fakeSampler{replies: map[string]string{
+		"README":       "```markdown\n# Orders\n\nHandles orders.\n```",
+		"pull request": "Adds CI and a Dockerfile.",
+		"CODEOWNERS":   "# owners\n* @acme/orders\n/.github/ @acme/platform",
+	}}
+
+	files, err := Generate(context.Background(), sampler, testConfig, testFiles)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	got := byPath(files)
+	if got[ReadmePath] != "# Orders\n\nHandles orders.\n" {
+		t.Errorf("README fence not stripped: %q", got[ReadmePath])
+	}
+	if got[PRDescriptionPath] != "Adds CI and a Dockerfile.\n" {
+		t.Errorf("unexpected PR description: %q", got[PRDescriptionPath])
+	}
+	if got[CodeownersPath] != "# owners\n* @acme/orders\n/.github/ @acme/platform\n" {
+		t.Errorf("unexpected CODEOWNERS: %q", got[CodeownersPath])
+	}
+
+	for _, p := range sampler.prompts {
+		if !strings.Contains(p, "Project name: orders") || !strings.Contains(p, "- Dockerfile (1 lines)") {
+			t.Errorf("prompt lacks the project summary:\n%s", p)
+		}
+	}
+}
+
+func TestGenerate_InvalidSampleFallsBack(t *testing.T) {
+	sampler := &fakeSampler{replies: map[string]string{
+		"CODEOWNERS": "Sure! Here is a CODEOWNERS file for you.",
+		"README":     strings.Repeat("x", maxDraftBytes+1),
+	}}
+
+	files, err := Generate(context.Background(), sampler, testConfig, testFiles)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	got := byPath(files)
+	if !strings.HasPrefix(got[ReadmePath], "# orders\n") {
+		t.Errorf("oversized README was not replaced by the fallback:\n%.80s", got[ReadmePath])
+	}
+	if !strings.HasPrefix(got[CodeownersPath], "# Suggested code owners for orders.") {
+		t.Errorf("invalid CODEOWNERS was not replaced by the fallback:\n%s", got[CodeownersPath])
+	}
+	// The empty PR description reply is also rejected.
+	if !strings.HasPrefix(got[PRDescriptionPath], "## Scaffold orders") {
+		t.Errorf("empty PR description was not replaced by the fallback:\n%s", got[PRDescriptionPath])
+	}
+}
+
+func TestGenerate_SamplerError(t *testing.T) {
+	files, err := Generate(context.Background(), &fakeSampler{err: errors.New("declined")}, testConfig, testFiles)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if len(files) != 3 {
+		t.Errorf("expected 3 fallback drafts, got %d", len(files))
+	}
+}
+
+func TestGenerate_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Generate(ctx, nil, testConfig, testFiles); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+// Package jsonyaml encodes values as YAML under their JSON field names, so
+// files and reports read the same in both formats.
+package jsonyaml
+
+import (
+	"encoding/json"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Encode writes v to w as block-style YAML with the JSON field names of v,
+// in field order.
+func Encode(w io.Writer, v any) error {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	// JSON is YAML; re-encoding the node in block style keeps the order.
+	var node yaml.Node
+	if err := yaml.Unmarshal(encoded, &node); err != nil {
+		return err
+	}
+	setBlockStyle(&node)
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+func setBlockStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		setBlockStyle(c)
+	}
+}
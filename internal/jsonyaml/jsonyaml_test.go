@@ -0,0 +1,23 @@
+package jsonyaml
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	v := struct {
+		Name  string            `json:"name"`
+		Empty string            `json:"empty,omitempty"`
+		Vars  map[string]string `json:"vars"`
+		Envs  []string          `json:"envs"`
+	}{Name: "api", Vars: map[string]string{"b": "2", "a": "1"}, Envs: []string{"dev", "prod"}}
+
+	var out bytes.Buffer
+	if err := Encode(&out, v); err != nil {
+		t.Fatal(err)
+	}
+	if want := "name: api\nvars:\n  a: \"1\"\n  b: \"2\"\nenvs:\n  - dev\n  - prod\n"; out.String() != want {
+		t.Errorf("Encode = %q, want %q", out.String(), want)
+	}
+}
@@ -0,0 +1,84 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestNew_RedactsSecrets(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, slog.LevelInfo).With("auth_token", "abc")
+	logger.Info("hello",
+		"user", "alice",
+		"Password", "hunter2",
+		slog.Group("request", "api_key", "k-123", "path", "/mcp"),
+	)
+
+	out := buf.String()
+	for _, secret := range []string{"abc", "hunter2", "k-123"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("output leaked %q: %s", secret, out)
+		}
+	}
+	for _, want := range []string{"user=alice", "auth_token=" + Redacted, "Password=" + Redacted, "request.api_key=" + Redacted, "request.path=/mcp"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q: %s", want, out)
+		}
+	}
+}
+
+func TestFanoutHandler_RespectsLevels(t *testing.T) {
+	var debug, warn bytes.Buffer
+	logger := slog.New(NewFanoutHandler(
+		slog.NewTextHandler(&debug, &slog.HandlerOptions{Level: slog.LevelDebug}),
+		slog.NewTextHandler(&warn, &slog.HandlerOptions{Level: slog.LevelWarn}),
+	))
+
+	logger.Debug("detail")
+	logger.Warn("problem")
+
+	if !strings.Contains(debug.String(), "detail") || !strings.Contains(debug.String(), "problem") {
+		t.Errorf("debug handler missing records: %s", debug.String())
+	}
+	if strings.Contains(warn.String(), "detail") || !strings.Contains(warn.String(), "problem") {
+		t.Errorf("warn handler got wrong records: %s", warn.String())
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	if FromContext(context.Background()) == nil {
+		t.Fatal("FromContext returned nil without a logger")
+	}
+
+	var buf bytes.Buffer
+	ctx := WithLogger(context.Background(), New(&buf, slog.LevelInfo))
+	FromContext(ctx).Info("from context")
+	if !strings.Contains(buf.String(), "from context") {
+		t.Errorf("logger from context did not write: %s", buf.String())
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	if l, err := ParseLevel("debug"); err != nil || l != slog.LevelDebug {
+		t.Errorf("ParseLevel(debug) = %v, %v", l, err)
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("ParseLevel(verbose) succeeded")
+	}
+}
+
+func TestMapAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	New(&buf, slog.LevelInfo).Info("args", slog.Group("arguments", MapAttrs(map[string]any{
+		"project_name": "demo",
+		"registry":     map[string]any{"password": "pw"},
+	})...))
+
+	out := buf.String()
+	if !strings.Contains(out, "arguments.project_name=demo") || !strings.Contains(out, "arguments.registry.password="+Redacted) {
+		t.Errorf("unexpected output: %s", out)
+	}
+}
@@ -0,0 +1,42 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/internal/detect"
+)
+
+// AnalyzeInput defines the input for the analyze_repository tool.
+type AnalyzeInput struct {
+	Directory string `json:"directory,omitempty" jsonschema:"Directory to analyze. Absolute, or relative to the first MCP root advertised by the client. Defaults to the first root."`
+}
+
+// HandleAnalyzeRepository implements the analyze_repository MCP tool.
+func HandleAnalyzeRepository(ctx context.Context, request *mcp.CallToolRequest, input AnalyzeInput) (*mcp.CallToolResult, *detect.Report, error) {
+	roots, err := listRootDirs(ctx, request)
+	if err != nil {
+		return nil, nil, err
+	}
+	if input.Directory == "" {
+		input.Directory = roots[0]
+	}
+	dir, err := resolveInRoots(input.Directory, roots)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	report, err := detect.Analyze(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var text strings.Builder
+	if err := report.WriteText(&text); err != nil {
+		return nil, nil, err
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: text.String()}},
+	}, report, nil
+}
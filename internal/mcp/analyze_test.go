@@ -0,0 +1,55 @@
+package mcp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleAnalyzeRepository(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/orders\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "Dockerfile"), []byte("FROM scratch\n"), 0644))
+	session := connectApplyClient(t, root)
+	ctx := context.Background()
+
+	res, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "analyze_repository", Arguments: map[string]any{}})
+	require.NoError(t, err)
+	require.False(t, res.IsError, "unexpected tool error: %v", res.Content)
+
+	report := res.StructuredContent.(map[string]any)
+	assert.Equal(t, root, report["directory"])
+	assert.Equal(t, map[string]any{
+		"project_name":  "orders",
+		"use_docker":    true,
+		"workflow_type": "go",
+		"with_actions":  true,
+		"with_docker":   true,
+	}, report["config"])
+	assert.Contains(t, res.Content[0].(*mcp.TextContent).Text, "go.mod found")
+
+	res, err = session.CallTool(ctx, &mcp.CallToolParams{Name: "analyze_repository", Arguments: map[string]any{"directory": "/"}})
+	require.NoError(t, err)
+	assert.True(t, res.IsError, "directories outside the roots must be refused")
+}
+
+func TestHandleAnalyzeRepository_HTTPConfinesRoots(t *testing.T) {
+	workspaceRoot, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	for _, dir := range []string{"", workspaceRoot} {
+		server := NewServer("test", &ServerOptions{WorkspaceRoot: dir, RemoteClients: true})
+		RegisterTools(server)
+		session := connectHTTPClient(t, server, "file:///")
+
+		res, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "analyze_repository", Arguments: map[string]any{"directory": "/etc"}})
+		require.NoError(t, err)
+		assert.True(t, res.IsError, "expected the file:/// root to be refused with workspace root %q", dir)
+	}
+}
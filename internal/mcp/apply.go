@@ -0,0 +1,199 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/internal/project"
+	"github.com/modelcontextprotocol/platform.mcp/internal/workspace"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+)
+
+// ApplyInput defines the input for the apply tool.
+type ApplyInput struct {
+	ProjectInput
+	Directory  string `json:"directory" jsonschema:"Target directory. Absolute, or relative to the first MCP root advertised by the client. Must lie inside one of the roots."`
+	Force      bool   `json:"force,omitempty" jsonschema:"Overwrite existing files instead of skipping them. Shorthand for on_conflict overwrite."`
+	OnConflict string `json:"on_conflict,omitempty" jsonschema:"What to do with existing files whose content differs: skip them, overwrite them, fail the call, or merge the generated changes into them against the copy stored under .platform/base, marking overlapping edits with conflict markers. Defaults to skip, or overwrite with force."`
+}
+
+// applyPolicies lists the conflict policies accepted by the apply tool.
+// Prompting has no user to ask.
+var applyPolicies = []workspace.Policy{workspace.PolicySkip, workspace.PolicyOverwrite, workspace.PolicyFail, workspace.PolicyMerge}
+
+// ApplyOutput reports what the apply tool did with each generated file.
+type ApplyOutput struct {
+	Directory string             `json:"directory"`
+	Files     []workspace.Result `json:"files"`
+}
+
+// RegisterApplyTool adds the apply tool, which writes to the client's
+// workspace. It is opt-in because unlike the other tools it has side effects.
+func RegisterApplyTool(server *mcp.Server) {
+	lookupTool("apply").register(server)
+}
+
+// HandleApply implements the apply MCP tool.
+func HandleApply(ctx context.Context, request *mcp.CallToolRequest, input ApplyInput) (*mcp.CallToolResult, ApplyOutput, error) {
+	roots, err := listRootDirs(ctx, request)
+	if err != nil {
+		return nil, ApplyOutput{}, err
+	}
+	dir, err := resolveInRoots(input.Directory, roots)
+	if err != nil {
+		return nil, ApplyOutput{}, err
+	}
+
+	base, err := loadProject(dir)
+	if err != nil {
+		return nil, ApplyOutput{}, err
+	}
+	cfg, err := input.resolveFrom(ctx, base)
+	if err != nil {
+		return nil, ApplyOutput{}, err
+	}
+	if err := authorizeTemplates(ctx, cfg); err != nil {
+		return nil, ApplyOutput{}, err
+	}
+
+	files, err := generateFiles(ctx, request, cfg, input.Drafts)
+	if err != nil {
+		return nil, ApplyOutput{}, err
+	}
+
+	opts := workspace.Options{
+		Policy: workspace.Policy(input.OnConflict),
+		Base:   workspace.Dir(filepath.Join(dir, project.BaseDir)),
+	}
+	if input.Force {
+		if opts.Policy != "" && opts.Policy != workspace.PolicyOverwrite {
+			return nil, ApplyOutput{}, fmt.Errorf("force cannot be combined with on_conflict %s", opts.Policy)
+		}
+		opts.Policy = workspace.PolicyOverwrite
+	}
+	results, err := workspace.Write(workspace.Dir(dir), files, opts)
+	if err != nil {
+		return nil, ApplyOutput{}, fmt.Errorf("apply failed and was rolled back: %w", err)
+	}
+	// The stored base is what the next merge starts from.
+	if err := project.Record(dir, project.Generation{Config: &cfg}, files, results); err != nil {
+		return nil, ApplyOutput{}, err
+	}
+
+	var report strings.Builder
+	for _, r := range results {
+		fmt.Fprintf(&report, "%s %s", r.Action, r.Path)
+		if r.Conflicts > 0 {
+			fmt.Fprintf(&report, " (%d conflicts)", r.Conflicts)
+		}
+		report.WriteString("\n")
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: report.String()}},
+	}, ApplyOutput{Directory: dir, Files: results}, nil
+}
+
+// workspaceKey carries the workspace set by withWorkspace.
+type workspaceKey struct{}
+
+type workspaceRoot struct {
+	dir    string
+	remote bool
+}
+
+// withWorkspace returns middleware that makes the server's workspace root
+// available to listRootDirs.
+func withWorkspace(dir string, remote bool) mcp.Middleware {
+	ws := workspaceRoot{dir: dir, remote: remote}
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			return next(context.WithValue(ctx, workspaceKey{}, ws), method, req)
+		}
+	}
+}
+
+// listRootDirs returns the client's file roots as resolved local directories,
+// keeping only those inside the server's workspace root when one is set.
+func listRootDirs(ctx context.Context, request *mcp.CallToolRequest) ([]string, error) {
+	if request == nil || request.Session == nil {
+		return nil, errors.New("apply requires a client session that advertises MCP roots")
+	}
+	ws, _ := ctx.Value(workspaceKey{}).(workspaceRoot)
+	if ws.remote && ws.dir == "" {
+		return nil, fmt.Errorf("%s is disabled: the server has no workspace root to confine remote clients to", request.Params.Name)
+	}
+	var workspaceDir string
+	if ws.dir != "" {
+		dir, err := workspace.ResolvePath(ws.dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve workspace root: %w", err)
+		}
+		workspaceDir = dir
+	}
+
+	res, err := request.Session.ListRoots(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list client roots: %w", err)
+	}
+
+	var dirs []string
+	for _, root := range res.Roots {
+		u, err := url.Parse(root.URI)
+		if err != nil || u.Scheme != "file" {
+			continue
+		}
+		dir, err := workspace.ResolvePath(filepath.FromSlash(u.Path))
+		if err != nil {
+			continue
+		}
+		if workspaceDir != "" && !workspace.Within(workspaceDir, dir) {
+			continue
+		}
+		dirs = append(dirs, dir)
+	}
+	if len(dirs) == 0 {
+		if workspaceDir != "" {
+			return nil, fmt.Errorf("client did not advertise any file:// roots inside the workspace root %s", workspaceDir)
+		}
+		return nil, errors.New("client did not advertise any file:// roots")
+	}
+	return dirs, nil
+}
+
+// resolveInRoots resolves dir against roots and rejects it unless it lies inside one of them.
+func resolveInRoots(dir string, roots []string) (string, error) {
+	if dir == "" {
+		return "", errors.New("directory is required")
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(roots[0], dir)
+	}
+
+	resolved, err := workspace.ResolvePath(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve directory: %w", err)
+	}
+	for _, root := range roots {
+		if workspace.Within(root, resolved) {
+			return resolved, nil
+		}
+	}
+	return "", fmt.Errorf("directory %s is outside the client's roots", dir)
+}
+
+// loadProject returns the project file in dir, or the zero Config if there
+// is none.
+func loadProject(dir string) (scaffold.Config, error) {
+	cfg, err := project.Load(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return scaffold.Config{}, nil
+	}
+	return cfg, err
+}
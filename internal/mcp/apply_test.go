@@ -0,0 +1,235 @@
+package mcp
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/internal/mcptest"
+	"github.com/modelcontextprotocol/platform.mcp/internal/project"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func connectApplyClient(t *testing.T, roots ...string) *mcp.ClientSession {
+	t.Helper()
+	server := NewServer("test", nil)
+	RegisterTools(server)
+	RegisterApplyTool(server)
+	return mcptest.Connect(t, server, &mcptest.Options{Roots: roots})
+}
+
+func callApply(t *testing.T, session *mcp.ClientSession, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	res, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "apply", Arguments: args})
+	require.NoError(t, err)
+	return res
+}
+
+func TestHandleApply(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	session := connectApplyClient(t, root)
+
+	args := map[string]any{
+		"project_name":  "apply-test",
+		"workflow_type": "go",
+		"with_actions":  true,
+		"directory":     "service",
+	}
+
+	res := callApply(t, session, args)
+	require.False(t, res.IsError, "unexpected tool error: %v", res.Content)
+
+	out := res.StructuredContent.(map[string]any)
+	assert.Equal(t, filepath.Join(root, "service"), out["directory"])
+	files := out["files"].([]any)
+	require.Len(t, files, 2)
+	for _, f := range files {
+		assert.Equal(t, "created", f.(map[string]any)["action"])
+	}
+	_, err = os.Stat(filepath.Join(root, "service", ".github", "workflows", "go.yaml"))
+	assert.NoError(t, err)
+
+	// A second run leaves the files alone; an edited file is skipped, and
+	// force overwrites it.
+	res = callApply(t, session, args)
+	for _, f := range res.StructuredContent.(map[string]any)["files"].([]any) {
+		assert.Equal(t, "unchanged", f.(map[string]any)["action"])
+	}
+
+	edited := filepath.Join(root, "service", ".github", "workflows", "go.yaml")
+	require.NoError(t, os.WriteFile(edited, []byte("edited\n"), 0644))
+	actions := func(res *mcp.CallToolResult) map[string]any {
+		t.Helper()
+		require.False(t, res.IsError, "unexpected tool error: %v", res.Content)
+		got := make(map[string]any)
+		for _, f := range res.StructuredContent.(map[string]any)["files"].([]any) {
+			got[f.(map[string]any)["path"].(string)] = f.(map[string]any)["action"]
+		}
+		return got
+	}
+	assert.Equal(t, "skipped", actions(callApply(t, session, args))[".github/workflows/go.yaml"])
+
+	args["on_conflict"] = "fail"
+	assert.True(t, callApply(t, session, args).IsError)
+
+	delete(args, "on_conflict")
+	args["force"] = true
+	assert.Equal(t, map[string]any{".github/workflows/ci.yaml": "unchanged", ".github/workflows/go.yaml": "overwritten"}, actions(callApply(t, session, args)))
+}
+
+func TestHandleApply_Merge(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	session := connectApplyClient(t, root)
+
+	files, err := scaffold.GenerateContext(context.Background(), scaffold.Config{ProjectName: "apply-test", WithDocker: true})
+	require.NoError(t, err)
+	generated := files[0].Content
+
+	// The stored base of a previous run lets local edits survive.
+	base := filepath.Join(root, project.BaseDir, "Dockerfile")
+	require.NoError(t, os.MkdirAll(filepath.Dir(base), 0755))
+	require.NoError(t, os.WriteFile(base, []byte("# old template\n"+generated), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "Dockerfile"), []byte("# old template\n"+generated+"# local edit\n"), 0644))
+
+	res := callApply(t, session, map[string]any{
+		"project_name": "apply-test",
+		"with_docker":  true,
+		"directory":    root,
+		"on_conflict":  "merge",
+	})
+	require.False(t, res.IsError, "unexpected tool error: %v", res.Content)
+
+	content, err := os.ReadFile(filepath.Join(root, "Dockerfile"))
+	require.NoError(t, err)
+	assert.Equal(t, generated+"# local edit\n", string(content))
+}
+
+func TestHandleApply_OutsideRoots(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	outside := t.TempDir()
+	session := connectApplyClient(t, root)
+
+	for _, dir := range []string{outside, "../escape", filepath.Join(root, "..")} {
+		res := callApply(t, session, map[string]any{
+			"project_name": "apply-test",
+			"with_actions": true,
+			"directory":    dir,
+		})
+		assert.True(t, res.IsError, "expected %s to be rejected", dir)
+	}
+
+	entries, err := os.ReadDir(outside)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestHandleApply_NoRoots(t *testing.T) {
+	session := connectApplyClient(t)
+
+	res := callApply(t, session, map[string]any{
+		"project_name": "apply-test",
+		"with_actions": true,
+		"directory":    t.TempDir(),
+	})
+	assert.True(t, res.IsError)
+}
+
+// connectHTTPClient serves server over the http transport and connects a
+// client that advertises roots, given as file:// URIs.
+func connectHTTPClient(t *testing.T, server *mcp.Server, roots ...string) *mcp.ClientSession {
+	t.Helper()
+	ts := httptest.NewServer(NewHTTPHandler(server, HTTPOptions{}))
+	t.Cleanup(ts.Close)
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "test"}, nil)
+	for _, uri := range roots {
+		client.AddRoots(&mcp.Root{URI: uri})
+	}
+	session, err := client.Connect(context.Background(), &mcp.StreamableClientTransport{Endpoint: ts.URL + "/mcp"}, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+func TestHandleApply_HTTPConfinesRoots(t *testing.T) {
+	workspaceRoot, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	outside := t.TempDir()
+	args := map[string]any{
+		"project_name": "apply-test",
+		"with_actions": true,
+		"directory":    outside,
+	}
+
+	tests := []struct {
+		name          string
+		workspaceRoot string
+	}{
+		{"no workspace root", ""},
+		{"outside workspace root", workspaceRoot},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := NewServer("test", &ServerOptions{WorkspaceRoot: tt.workspaceRoot, RemoteClients: true})
+			RegisterApplyTool(server)
+			session := connectHTTPClient(t, server, "file:///")
+
+			res := callApply(t, session, args)
+			assert.True(t, res.IsError, "expected the file:/// root to be refused")
+		})
+	}
+
+	entries, err := os.ReadDir(outside)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	server := NewServer("test", &ServerOptions{WorkspaceRoot: workspaceRoot, RemoteClients: true})
+	RegisterApplyTool(server)
+	dir := filepath.Join(workspaceRoot, "svc")
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	session := connectHTTPClient(t, server, "file:///", "file://"+filepath.ToSlash(dir))
+
+	args["directory"] = dir
+	res := callApply(t, session, args)
+	require.False(t, res.IsError, "unexpected tool error: %v", res.Content)
+	assert.FileExists(t, filepath.Join(dir, ".github", "workflows", "go.yaml"))
+}
+
+func TestHandleApply_RecordsBase(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	session := connectApplyClient(t, root)
+	args := map[string]any{
+		"project_name": "apply-test",
+		"with_docker":  true,
+		"directory":    root,
+	}
+
+	res := callApply(t, session, args)
+	require.False(t, res.IsError, "unexpected tool error: %v", res.Content)
+	for _, path := range []string{project.ConfigFile, project.LockFile, filepath.Join(project.BaseDir, "Dockerfile")} {
+		_, err := os.Stat(filepath.Join(root, path))
+		assert.NoError(t, err, "apply did not record %s", path)
+	}
+
+	// Merging against the recorded base keeps a local edit without
+	// conflicts.
+	dockerfile := filepath.Join(root, "Dockerfile")
+	generated, err := os.ReadFile(dockerfile)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(dockerfile, append(generated, "# local edit\n"...), 0644))
+	args["on_conflict"] = "merge"
+	res = callApply(t, session, args)
+	require.False(t, res.IsError, "unexpected tool error: %v", res.Content)
+	file := res.StructuredContent.(map[string]any)["files"].([]any)[0].(map[string]any)
+	assert.Equal(t, "unchanged", file["action"])
+	assert.Nil(t, file["conflicts"])
+}
@@ -0,0 +1,43 @@
+package mcp
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/internal/archive"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+)
+
+// ArchiveURIPrefix prefixes the URI of the archives embedded in tool results.
+// The archives are not listed as resources; they only exist in the result.
+const ArchiveURIPrefix = "platform-mcp://archives/"
+
+// archiveFormats lists the formats accepted by the archive input.
+var archiveFormats = []string{archive.FormatTar, archive.FormatZip}
+
+// filesContent returns files as tool result content: one text block per
+// file, or, when archiveFormat is set, a single embedded resource holding
+// the files packed in that format, named after name.
+func filesContent(files []scaffold.File, archiveFormat, name string) ([]mcp.Content, error) {
+	if archiveFormat == "" {
+		var content []mcp.Content
+		for _, f := range files {
+			content = append(content, &mcp.TextContent{
+				Text: fmt.Sprintf("--- FILE: %s ---\n%s", f.Path, f.Content),
+			})
+		}
+		return content, nil
+	}
+
+	var buf bytes.Buffer
+	if err := archive.Write(&buf, archiveFormat, files); err != nil {
+		return nil, err
+	}
+	// The SDK encodes Blob as base64.
+	return []mcp.Content{&mcp.EmbeddedResource{Resource: &mcp.ResourceContents{
+		URI:      ArchiveURIPrefix + name + archive.Extension(archiveFormat),
+		MIMEType: archive.MIMEType(archiveFormat),
+		Blob:     buf.Bytes(),
+	}}}, nil
+}
@@ -0,0 +1,107 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/internal/auth"
+	"github.com/modelcontextprotocol/platform.mcp/internal/logging"
+	"github.com/modelcontextprotocol/platform.mcp/internal/templates"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+)
+
+// CodeUnauthorized is the JSON-RPC error code returned when the caller is not
+// authenticated or the policy does not grant the requested tool or template.
+const CodeUnauthorized = -32001
+
+// Authorize returns middleware that enforces policy on every tools/list,
+// tools/call, resources/list and resources/read request. Template resources
+// follow the template grants. It must only be used with a transport that
+// authenticates callers, since requests without token info are rejected.
+func Authorize(policy *auth.Policy) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			switch method {
+			case "tools/list", "tools/call", "resources/list", "resources/read":
+			default:
+				return next(ctx, method, req)
+			}
+
+			extra := req.GetExtra()
+			if extra == nil || extra.TokenInfo == nil || extra.TokenInfo.UserID == "" {
+				return nil, unauthorized("authentication required")
+			}
+			principal := auth.Principal{
+				Identity: extra.TokenInfo.UserID,
+				Grant:    policy.GrantFor(extra.TokenInfo.UserID),
+			}
+			ctx = auth.WithPrincipal(ctx, principal)
+
+			switch method {
+			case "tools/call":
+				name := req.GetParams().(*mcp.CallToolParamsRaw).Name
+				if !principal.Grant.AllowsTool(name) {
+					logging.FromContext(ctx).Warn("tool call denied", "identity", principal.Identity, "tool", name)
+					return nil, unauthorized(fmt.Sprintf("%s is not allowed to call tool %q", principal.Identity, name))
+				}
+				return next(ctx, method, req)
+			case "resources/read":
+				uri := req.GetParams().(*mcp.ReadResourceParams).URI
+				if name, ok := templateName(uri); ok && !principal.Grant.AllowsTemplate(name) {
+					logging.FromContext(ctx).Warn("template denied", "identity", principal.Identity, "template", name)
+					return nil, unauthorized(fmt.Sprintf("%s is not allowed to use template %q", principal.Identity, name))
+				}
+				return next(ctx, method, req)
+			case "resources/list":
+				res, err := next(ctx, method, req)
+				if err != nil {
+					return nil, err
+				}
+				list := res.(*mcp.ListResourcesResult)
+				list.Resources = slices.DeleteFunc(list.Resources, func(r *mcp.Resource) bool {
+					name, ok := templateName(r.URI)
+					return ok && !principal.Grant.AllowsTemplate(name)
+				})
+				return list, nil
+			default:
+				res, err := next(ctx, method, req)
+				if err != nil {
+					return nil, err
+				}
+				list := res.(*mcp.ListToolsResult)
+				list.Tools = slices.DeleteFunc(list.Tools, func(t *mcp.Tool) bool {
+					return !principal.Grant.AllowsTool(t.Name)
+				})
+				return list, nil
+			}
+		}
+	}
+}
+
+// authorizeTemplates rejects cfg if it would render a template the caller's
+// grant does not cover. Unauthenticated transports are not restricted.
+func authorizeTemplates(ctx context.Context, cfg scaffold.Config) error {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	manifest, err := templates.GetManifest()
+	if err != nil {
+		return fmt.Errorf("failed to get template manifest: %w", err)
+	}
+	for _, mapping := range scaffold.FilterTemplates(manifest, cfg) {
+		if !principal.Grant.AllowsTemplate(mapping.Name) {
+			logging.FromContext(ctx).Warn("template denied", "identity", principal.Identity, "template", mapping.Name)
+			return unauthorized(fmt.Sprintf("%s is not allowed to use template %q", principal.Identity, mapping.Name))
+		}
+	}
+	return nil
+}
+
+func unauthorized(msg string) error {
+	return &jsonrpc.Error{Code: CodeUnauthorized, Message: msg}
+}
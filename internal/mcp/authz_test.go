@@ -0,0 +1,181 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/internal/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bearerTransport struct {
+	token string
+}
+
+func (b bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+b.token)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func newAuthorizedTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := NewServer("test", &ServerOptions{
+		Policy: &auth.Policy{
+			Default: auth.Grant{
+				Tools:     []string{"generate_workflows"},
+				Templates: []string{"actions-workflow", "*-workflow"},
+			},
+			Identities: map[string]auth.Grant{
+				"alice": {Tools: []string{auth.Wildcard}, Templates: []string{auth.Wildcard}},
+				"carol": {Tools: []string{"list_templates"}, Templates: []string{"*-workflow"}},
+			},
+		},
+	})
+	RegisterTools(server)
+	_, err := RegisterTemplateResources(context.Background(), server)
+	require.NoError(t, err)
+
+	verifier := auth.NewStaticTokenVerifier([]auth.StaticToken{
+		{Subject: "alice", Token: "alice-token"},
+		{Subject: "bob", Token: "bob-token"},
+		{Subject: "carol", Token: "carol-token"},
+	})
+	ts := httptest.NewServer(NewHTTPHandler(server, HTTPOptions{Verifier: verifier}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func connectWithToken(t *testing.T, url, token string) (*mcp.ClientSession, error) {
+	t.Helper()
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "test"}, nil)
+	return client.Connect(context.Background(), &mcp.StreamableClientTransport{
+		Endpoint:   url + "/mcp",
+		HTTPClient: &http.Client{Transport: bearerTransport{token: token}},
+	}, nil)
+}
+
+func TestAuthorize_RejectsMissingToken(t *testing.T) {
+	ts := newAuthorizedTestServer(t)
+
+	_, err := connectWithToken(t, ts.URL, "wrong-token")
+	assert.Error(t, err)
+
+	resp, err := http.Get(ts.URL + "/healthz")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "health probe must stay unauthenticated")
+}
+
+func TestAuthorize_Policy(t *testing.T) {
+	ts := newAuthorizedTestServer(t)
+	ctx := context.Background()
+
+	alice, err := connectWithToken(t, ts.URL, "alice-token")
+	require.NoError(t, err)
+	defer alice.Close()
+
+	bob, err := connectWithToken(t, ts.URL, "bob-token")
+	require.NoError(t, err)
+	defer bob.Close()
+
+	aliceTools, err := alice.ListTools(ctx, nil)
+	require.NoError(t, err)
+	assert.Len(t, aliceTools.Tools, 7)
+
+	bobTools, err := bob.ListTools(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, bobTools.Tools, 1)
+	assert.Equal(t, "generate_workflows", bobTools.Tools[0].Name)
+
+	workflows := &mcp.CallToolParams{
+		Name:      "generate_workflows",
+		Arguments: map[string]any{"project_name": "authz", "workflow_type": "go", "docker": false},
+	}
+	res, err := bob.CallTool(ctx, workflows)
+	require.NoError(t, err)
+	assert.False(t, res.IsError)
+
+	withDocker := &mcp.CallToolParams{
+		Name:      "generate_workflows",
+		Arguments: map[string]any{"project_name": "authz", "workflow_type": "go", "docker": true},
+	}
+	_, err = bob.CallTool(ctx, withDocker)
+	assertUnauthorized(t, err)
+
+	res, err = alice.CallTool(ctx, withDocker)
+	require.NoError(t, err)
+	assert.False(t, res.IsError)
+
+	_, err = bob.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "generate",
+		Arguments: map[string]any{"project_name": "authz"},
+	})
+	assertUnauthorized(t, err)
+}
+
+func assertUnauthorized(t *testing.T, err error) {
+	t.Helper()
+	var wireErr *jsonrpc.Error
+	require.True(t, errors.As(err, &wireErr), "expected JSON-RPC error, got %v", err)
+	assert.Equal(t, int64(CodeUnauthorized), wireErr.Code)
+}
+
+func TestAuthorize_TemplateResources(t *testing.T) {
+	ts := newAuthorizedTestServer(t)
+	ctx := context.Background()
+
+	bob, err := connectWithToken(t, ts.URL, "bob-token")
+	require.NoError(t, err)
+	defer bob.Close()
+
+	res, err := bob.ListResources(ctx, nil)
+	require.NoError(t, err)
+	var uris []string
+	for _, r := range res.Resources {
+		uris = append(uris, r.URI)
+	}
+	assert.Contains(t, uris, ManifestURI)
+	assert.Contains(t, uris, TemplateURIPrefix+"go-workflow")
+	assert.NotContains(t, uris, TemplateURIPrefix+"dockerfile")
+
+	_, err = bob.ReadResource(ctx, &mcp.ReadResourceParams{URI: TemplateURIPrefix + "go-workflow"})
+	assert.NoError(t, err)
+	_, err = bob.ReadResource(ctx, &mcp.ReadResourceParams{URI: TemplateURIPrefix + "dockerfile"})
+	var rpcErr *jsonrpc.Error
+	require.True(t, errors.As(err, &rpcErr), "got %v", err)
+	assert.Equal(t, int64(CodeUnauthorized), rpcErr.Code)
+}
+
+func TestAuthorize_ListTemplates(t *testing.T) {
+	ts := newAuthorizedTestServer(t)
+	ctx := context.Background()
+
+	names := func(token string) []string {
+		t.Helper()
+		session, err := connectWithToken(t, ts.URL, token)
+		require.NoError(t, err)
+		defer session.Close()
+		res, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "list_templates", Arguments: map[string]any{}})
+		require.NoError(t, err)
+		require.False(t, res.IsError, "unexpected tool error: %v", res.Content)
+		var names []string
+		for _, tmpl := range res.StructuredContent.(map[string]any)["templates"].([]any) {
+			names = append(names, tmpl.(map[string]any)["name"].(string))
+		}
+		return names
+	}
+
+	assert.Contains(t, names("alice-token"), "dockerfile")
+	carol := names("carol-token")
+	assert.Contains(t, carol, "go-workflow")
+	for _, name := range carol {
+		assert.Regexp(t, `-workflow$`, name, "carol may only see workflow templates")
+	}
+}
@@ -0,0 +1,52 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+)
+
+// BatchComponent is one component of a generate_batch call.
+type BatchComponent struct {
+	Path string `json:"path,omitempty" jsonschema:"Directory of the component relative to the repository root, e.g. services/api. Empty means the root."`
+	GenerateInput
+}
+
+// GenerateBatchInput defines the input for the generate_batch tool.
+type GenerateBatchInput struct {
+	Components []BatchComponent `json:"components" jsonschema:"Components to generate, each with its own options and sub-path"`
+	Archive    string           `json:"archive,omitempty" jsonschema:"Return the merged files as a single embedded resource holding a base64-encoded tar or zip archive, keeping file modes, instead of one text block per file."`
+}
+
+var errBatchDrafts = errors.New("drafts are not supported by generate_batch; call generate with drafts for each component instead")
+
+// HandleGenerateBatch implements the generate_batch MCP tool.
+func HandleGenerateBatch(ctx context.Context, request *mcp.CallToolRequest, input GenerateBatchInput) (*mcp.CallToolResult, any, error) {
+	components := make([]scaffold.Component, len(input.Components))
+	for i, c := range input.Components {
+		if c.Drafts {
+			return nil, nil, errBatchDrafts
+		}
+		components[i] = scaffold.Component{Path: c.Path, Config: c.resolve(ctx)}
+		if err := authorizeTemplates(ctx, components[i].Config); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	files, err := scaffold.GenerateBatch(withProgress(ctx, request), components)
+	if err != nil {
+		return nil, nil, fmt.Errorf("generation failed: %w", err)
+	}
+
+	content, err := filesContent(files, input.Archive, "scaffold")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &mcp.CallToolResult{
+		Content: content,
+	}, nil, nil
+}
@@ -0,0 +1,38 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleGenerateBatch(t *testing.T) {
+	res, _, err := HandleGenerateBatch(context.Background(), &mcp.CallToolRequest{}, GenerateBatchInput{
+		Components: []BatchComponent{
+			{Path: "services/api", GenerateInput: GenerateInput{ProjectName: "api", WithDocker: true}},
+			{Path: "services/web", GenerateInput: GenerateInput{ProjectName: "web", WithDocker: true}},
+		},
+	})
+	require.NoError(t, err)
+
+	var text string
+	for _, c := range res.Content {
+		text += c.(*mcp.TextContent).Text
+	}
+	assert.Contains(t, text, "--- FILE: services/api/Dockerfile ---")
+	assert.Contains(t, text, "--- FILE: services/web/Dockerfile ---")
+}
+
+func TestHandleGenerateBatch_Collision(t *testing.T) {
+	_, _, err := HandleGenerateBatch(context.Background(), &mcp.CallToolRequest{}, GenerateBatchInput{
+		Components: []BatchComponent{
+			{GenerateInput: GenerateInput{ProjectName: "api", WithDocker: true}},
+			{GenerateInput: GenerateInput{ProjectName: "web", WithDocker: true}},
+		},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Dockerfile (from api, web)")
+}
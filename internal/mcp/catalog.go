@@ -0,0 +1,207 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// VersionMetaKey is the _meta key carrying a tool's catalog version.
+const VersionMetaKey = "platform-mcp/version"
+
+// ToolSpec is one entry of the tool catalog. Version changes whenever the
+// tool's input, output or behaviour changes, so gateways can review the
+// catalog diff before rolling out a new server.
+type ToolSpec struct {
+	Name        string
+	Title       string
+	Description string
+	Version     string
+	// OptIn tools are not registered by RegisterTools.
+	OptIn bool
+	// ReadOnly tools never modify the client's environment.
+	ReadOnly bool
+	// Destructive tools may overwrite existing data. Only meaningful when
+	// ReadOnly is false.
+	Destructive bool
+	// Idempotent tools have no additional effect when called again with the
+	// same arguments.
+	Idempotent bool
+
+	add func(*mcp.Server, *mcp.Tool)
+}
+
+// Catalog declares every tool the server can expose.
+var Catalog = []ToolSpec{
+	{
+		Name:        "generate_workflows",
+		Title:       "Generate workflows",
+		Description: "Generate GitHub Actions workflows for a project",
+		Version:     "1.5.0",
+		ReadOnly:    true,
+		Idempotent:  true,
+		add:         addTool(HandleGenerateWorkflows),
+	},
+	{
+		Name:        "generate",
+		Title:       "Generate scaffolding",
+		Description: "Generate project scaffolding including Actions, Docker, and Flux, optionally packed into a tar or zip archive returned as an embedded resource",
+		Version:     "1.6.0",
+		ReadOnly:    true,
+		Idempotent:  true,
+		add:         addTool(HandleGenerate),
+	},
+	{
+		Name:        "generate_batch",
+		Title:       "Generate scaffolding for several components",
+		Description: "Generate scaffolding for several components at once, such as services in a monorepo. Each component has its own options and sub-path; the merged file set is returned, optionally as a tar or zip archive, and the call fails if two components would write the same file.",
+		Version:     "1.6.0",
+		ReadOnly:    true,
+		Idempotent:  true,
+		add:         addTool(HandleGenerateBatch),
+	},
+	{
+		Name:        "preview",
+		Title:       "Preview scaffolding changes",
+		Description: "Compare generated scaffolding with the files in a directory inside one of the client's MCP roots and return unified diffs. Options left unset are read from the directory's .platform.yaml project file, if any. Nothing is written.",
+		Version:     "1.6.0",
+		ReadOnly:    true,
+		Idempotent:  true,
+		add:         addTool(HandlePreview),
+	},
+	{
+		Name:        "analyze_repository",
+		Title:       "Analyze repository",
+		Description: "Inspect a directory inside one of the client's MCP roots (go.mod, package.json, tsconfig.json, pyproject.toml, requirements.txt, Dockerfile, .github/workflows, deploy/) and suggest the generate options that match it, with a confidence note for each",
+		Version:     "1.0.0",
+		ReadOnly:    true,
+		Idempotent:  true,
+		add:         addTool(HandleAnalyzeRepository),
+	},
+	{
+		Name:        "list_templates",
+		Title:       "List templates",
+		Description: "List every template mapping the caller may use (name, source, target, condition) and whether it would be generated for the given options",
+		Version:     "1.3.0",
+		ReadOnly:    true,
+		Idempotent:  true,
+		add:         addTool(HandleListTemplates),
+	},
+	{
+		Name:        "describe_config",
+		Title:       "Describe configuration",
+		Description: "Describe the scaffold configuration as a JSON Schema, with enums, defaults and explanations for every option",
+		Version:     "1.4.0",
+		ReadOnly:    true,
+		Idempotent:  true,
+		add:         addTool(HandleDescribeConfig),
+	},
+	{
+		Name:        "apply",
+		Title:       "Apply scaffolding",
+		Description: "Generate project scaffolding and write it into a directory inside one of the client's MCP roots. Options left unset are read from the directory's .platform.yaml project file, if any. Existing files that differ are skipped, overwritten, merged or fail the call, as on_conflict selects; files already up to date are reported as unchanged. Writes are all or nothing: if any file cannot be written, the ones already written are restored. The run is then recorded under .platform, with the generated files stored as the base of the next merge.",
+		Version:     "1.9.0",
+		OptIn:       true,
+		Destructive: true,
+		Idempotent:  true,
+		add:         addTool(HandleApply),
+	},
+}
+
+// addTool adapts a typed handler to the catalog's registration hook. The
+// input schema is derived with InputSchema, so enums, defaults and
+// deprecations are advertised and enforced.
+func addTool[In, Out any](handler mcp.ToolHandlerFor[In, Out]) func(*mcp.Server, *mcp.Tool) {
+	return func(server *mcp.Server, tool *mcp.Tool) {
+		schema, err := InputSchema[In]()
+		if err != nil {
+			panic(fmt.Sprintf("tool %s: %v", tool.Name, err))
+		}
+		tool.InputSchema = schema
+		mcp.AddTool(server, tool, handler)
+	}
+}
+
+// Tool returns the MCP tool definition for the spec, without schemas. They
+// are derived from the handler's types when the tool is registered.
+func (s ToolSpec) Tool() *mcp.Tool {
+	destructive := s.Destructive && !s.ReadOnly
+	openWorld := false
+	return &mcp.Tool{
+		Meta:        mcp.Meta{VersionMetaKey: s.Version},
+		Name:        s.Name,
+		Title:       s.Title,
+		Description: s.Description,
+		Annotations: &mcp.ToolAnnotations{
+			Title:           s.Title,
+			ReadOnlyHint:    s.ReadOnly,
+			DestructiveHint: &destructive,
+			IdempotentHint:  s.Idempotent,
+			OpenWorldHint:   &openWorld,
+		},
+	}
+}
+
+func (s ToolSpec) register(server *mcp.Server) {
+	s.add(server, s.Tool())
+}
+
+// lookupTool returns the catalog entry for name. It panics for unknown
+// names, which can only happen through a programming error.
+func lookupTool(name string) ToolSpec {
+	for _, entry := range Catalog {
+		if entry.Name == name {
+			return entry
+		}
+	}
+	panic(fmt.Sprintf("tool %q is not in the catalog", name))
+}
+
+// CatalogEntry is a catalog tool as clients see it, schemas included.
+type CatalogEntry struct {
+	*mcp.Tool
+	Version string `json:"version"`
+	OptIn   bool   `json:"optIn,omitempty"`
+}
+
+// ListCatalog returns every catalog tool, opt-in tools included, with the
+// input and output schemas the server advertises in tools/list.
+func ListCatalog(ctx context.Context) ([]CatalogEntry, error) {
+	server := NewServer("catalog", nil)
+	if err := EnableTools(server, ToolNames()); err != nil {
+		return nil, err
+	}
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer serverSession.Close()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "catalog", Version: "catalog"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer session.Close()
+
+	listed := make(map[string]*mcp.Tool)
+	for tool, err := range session.Tools(ctx, nil) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tools: %w", err)
+		}
+		listed[tool.Name] = tool
+	}
+
+	entries := make([]CatalogEntry, 0, len(Catalog))
+	for _, spec := range Catalog {
+		tool, ok := listed[spec.Name]
+		if !ok {
+			return nil, fmt.Errorf("tool %q was not registered", spec.Name)
+		}
+		entries = append(entries, CatalogEntry{Tool: tool, Version: spec.Version, OptIn: spec.OptIn})
+	}
+	return entries, nil
+}
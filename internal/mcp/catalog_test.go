@@ -0,0 +1,90 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/internal/mcptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalog_Complete(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, spec := range Catalog {
+		assert.False(t, seen[spec.Name], "duplicate tool %s", spec.Name)
+		seen[spec.Name] = true
+
+		assert.NotEmpty(t, spec.Title, spec.Name)
+		assert.NotEmpty(t, spec.Description, spec.Name)
+		assert.Regexp(t, `^\d+\.\d+\.\d+$`, spec.Version, spec.Name)
+		assert.NotNil(t, spec.add, spec.Name)
+		if spec.ReadOnly {
+			assert.False(t, spec.Destructive, "read-only tool %s marked destructive", spec.Name)
+		}
+	}
+}
+
+func TestRegisterTools_UsesCatalog(t *testing.T) {
+	ctx := context.Background()
+	server := NewServer("test", nil)
+	RegisterTools(server)
+
+	session := mcptest.Connect(t, server, nil)
+
+	res, err := session.ListTools(ctx, nil)
+	require.NoError(t, err)
+
+	listed := make(map[string]*mcp.Tool)
+	for _, tool := range res.Tools {
+		listed[tool.Name] = tool
+	}
+	for _, spec := range Catalog {
+		tool, ok := listed[spec.Name]
+		if spec.OptIn {
+			assert.False(t, ok, "opt-in tool %s registered by default", spec.Name)
+			continue
+		}
+		require.True(t, ok, "tool %s not registered", spec.Name)
+		assert.Equal(t, spec.Title, tool.Title)
+		assert.Equal(t, spec.Version, tool.Meta[VersionMetaKey])
+		require.NotNil(t, tool.Annotations)
+		assert.Equal(t, spec.ReadOnly, tool.Annotations.ReadOnlyHint)
+		assert.Equal(t, spec.Idempotent, tool.Annotations.IdempotentHint)
+		require.NotNil(t, tool.Annotations.DestructiveHint)
+		assert.Equal(t, spec.Destructive, *tool.Annotations.DestructiveHint)
+	}
+}
+
+func TestListCatalog(t *testing.T) {
+	entries, err := ListCatalog(context.Background())
+	require.NoError(t, err)
+	require.Len(t, entries, len(Catalog))
+
+	for i, entry := range entries {
+		assert.Equal(t, Catalog[i].Name, entry.Name)
+		assert.Equal(t, Catalog[i].Version, entry.Version)
+		assert.Equal(t, Catalog[i].OptIn, entry.OptIn)
+		assert.NotNil(t, entry.InputSchema, entry.Name)
+	}
+
+	apply := entries[len(entries)-1]
+	assert.Equal(t, "apply", apply.Name)
+	assert.False(t, apply.Annotations.ReadOnlyHint)
+	assert.True(t, *apply.Annotations.DestructiveHint)
+	assert.NotNil(t, apply.OutputSchema)
+}
+
+func TestEnableTools(t *testing.T) {
+	server := NewServer("test", nil)
+	require.NoError(t, EnableTools(server, []string{"generate", "apply"}))
+
+	var names []string
+	for _, tool := range mcptest.ListTools(t, mcptest.Connect(t, server, nil)) {
+		names = append(names, tool.Name)
+	}
+	assert.ElementsMatch(t, []string{"generate", "apply"}, names)
+
+	assert.ErrorContains(t, EnableTools(NewServer("test", nil), []string{"generate", "deploy"}), `unknown tool "deploy"`)
+}
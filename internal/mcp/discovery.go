@@ -0,0 +1,72 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/internal/auth"
+	"github.com/modelcontextprotocol/platform.mcp/internal/templates"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+)
+
+// ListTemplatesInput defines the input for the list_templates tool. The
+// fields mirror the scaffold options that decide which templates apply.
+type ListTemplatesInput struct {
+	UseDocker    bool   `json:"use_docker,omitempty" jsonschema:"Evaluate conditions with the legacy Docker switch set"`
+	WorkflowType string `json:"workflow_type,omitempty" jsonschema:"Evaluate conditions for this workflow type"`
+	WithActions  bool   `json:"with_actions,omitempty" jsonschema:"Evaluate conditions with GitHub Actions enabled"`
+	WithDocker   bool   `json:"with_docker,omitempty" jsonschema:"Evaluate conditions with Docker enabled"`
+	WithFlux     bool   `json:"with_flux,omitempty" jsonschema:"Evaluate conditions with Flux CD enabled"`
+}
+
+// TemplateInfo describes one manifest mapping.
+type TemplateInfo struct {
+	templates.TemplateMapping
+	Generated bool `json:"generated"`
+}
+
+// ListTemplatesOutput lists every manifest mapping the caller may use.
+type ListTemplatesOutput struct {
+	Templates []TemplateInfo `json:"templates"`
+}
+
+// HandleListTemplates implements the list_templates MCP tool.
+func HandleListTemplates(ctx context.Context, request *mcp.CallToolRequest, input ListTemplatesInput) (*mcp.CallToolResult, ListTemplatesOutput, error) {
+	manifest, err := templates.GetManifest()
+	if err != nil {
+		return nil, ListTemplatesOutput{}, fmt.Errorf("failed to get template manifest: %w", err)
+	}
+
+	cfg := scaffold.Config{
+		UseDocker:    input.UseDocker,
+		WorkflowType: input.WorkflowType,
+		WithActions:  input.WithActions,
+		WithDocker:   input.WithDocker,
+		WithFlux:     input.WithFlux,
+	}
+
+	// Like template resources, templates outside the caller's grant are
+	// left out.
+	principal, restricted := auth.PrincipalFromContext(ctx)
+	out := ListTemplatesOutput{Templates: make([]TemplateInfo, 0, len(manifest.Templates))}
+	for _, mapping := range manifest.Templates {
+		if restricted && !principal.Grant.AllowsTemplate(mapping.Name) {
+			continue
+		}
+		out.Templates = append(out.Templates, TemplateInfo{
+			TemplateMapping: mapping,
+			Generated:       scaffold.ShouldGenerate(mapping.Condition, cfg),
+		})
+	}
+	return nil, out, nil
+}
+
+// HandleDescribeConfig implements the describe_config MCP tool.
+func HandleDescribeConfig(ctx context.Context, request *mcp.CallToolRequest, input struct{}) (*mcp.CallToolResult, any, error) {
+	schema, err := ConfigSchema()
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, schema, nil
+}
@@ -0,0 +1,62 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleListTemplates(t *testing.T) {
+	_, out, err := HandleListTemplates(context.Background(), &mcp.CallToolRequest{}, ListTemplatesInput{
+		WorkflowType: "python",
+		WithActions:  true,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, out.Templates)
+
+	generated := map[string]bool{}
+	for _, tmpl := range out.Templates {
+		assert.NotEmpty(t, tmpl.Source)
+		assert.NotEmpty(t, tmpl.Target)
+		assert.NotEmpty(t, tmpl.Condition)
+		generated[tmpl.Name] = tmpl.Generated
+	}
+	assert.True(t, generated["actions-workflow"])
+	assert.True(t, generated["python-workflow"])
+	assert.False(t, generated["go-workflow"])
+	assert.False(t, generated["dockerfile"])
+}
+
+func TestConfigSchema(t *testing.T) {
+	schema, err := ConfigSchema()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"project_name"}, schema.Required)
+
+	for name, prop := range schema.Properties {
+		assert.NotEmpty(t, prop.Description, "property %s has no description", name)
+	}
+
+	workflowType := schema.Properties["workflow_type"]
+	require.NotNil(t, workflowType)
+	assert.Len(t, workflowType.Enum, len(scaffold.WorkflowTypes))
+	// The server configuration decides the default workflow type.
+	assert.Nil(t, workflowType.Default)
+	assert.Contains(t, workflowType.Description, `falling back to "go"`)
+	assert.JSONEq(t, `false`, string(schema.Properties["with_flux"].Default))
+	assert.Nil(t, schema.Properties["project_name"].Default)
+
+	// Every scaffold.Config field must be described.
+	raw, err := json.Marshal(scaffold.Config{ProjectName: "x", WorkflowType: "go", UseDocker: true, WithActions: true, WithDocker: true, WithFlux: true, Org: "o", Registry: "r", Branch: "b"})
+	require.NoError(t, err)
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(raw, &fields))
+	for name := range fields {
+		assert.Contains(t, schema.Properties, name)
+	}
+}
@@ -0,0 +1,67 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/internal/drafts"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+)
+
+// sessionSampler samples through the client's LLM with sampling/createMessage.
+type sessionSampler struct {
+	session *mcp.ServerSession
+}
+
+func (s sessionSampler) Sample(ctx context.Context, systemPrompt, prompt string, maxTokens int64) (string, error) {
+	res, err := s.session.CreateMessage(ctx, &mcp.CreateMessageParams{
+		SystemPrompt:   systemPrompt,
+		IncludeContext: "none",
+		MaxTokens:      maxTokens,
+		Messages: []*mcp.SamplingMessage{
+			{Role: "user", Content: &mcp.TextContent{Text: prompt}},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	text, ok := res.Content.(*mcp.TextContent)
+	if !ok {
+		return "", fmt.Errorf("client returned %T content, want text", res.Content)
+	}
+	return text.Text, nil
+}
+
+// samplerFor returns a sampler for the calling client, or nil if the client
+// does not support sampling.
+func samplerFor(request *mcp.CallToolRequest) drafts.Sampler {
+	if request == nil || request.Session == nil {
+		return nil
+	}
+	params := request.Session.InitializeParams()
+	if params == nil || params.Capabilities == nil || params.Capabilities.Sampling == nil {
+		return nil
+	}
+	return sessionSampler{session: request.Session}
+}
+
+// generateFiles renders the scaffold for cfg, as returned by
+// GenerateInput.resolve, plus the drafted README, PR description and
+// CODEOWNERS files when withDrafts is set.
+func generateFiles(ctx context.Context, request *mcp.CallToolRequest, cfg scaffold.Config, withDrafts bool) ([]scaffold.File, error) {
+	generator := scaffold.NewProjectGenerator()
+	files, err := generator.Generate(withProgress(ctx, request), cfg)
+	if err != nil {
+		return nil, fmt.Errorf("generation failed: %w", err)
+	}
+	if !withDrafts {
+		return files, nil
+	}
+
+	drafted, err := drafts.Generate(ctx, samplerFor(request), cfg, files)
+	if err != nil {
+		return nil, fmt.Errorf("drafting failed: %w", err)
+	}
+	return append(files, drafted...), nil
+}
@@ -0,0 +1,101 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/internal/mcptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func connectSamplingClient(t *testing.T, handler func(context.Context, *mcp.CreateMessageRequest) (*mcp.CreateMessageResult, error)) *mcp.ClientSession {
+	t.Helper()
+	server := NewServer("test", nil)
+	RegisterTools(server)
+	return mcptest.Connect(t, server, &mcptest.Options{
+		Client: &mcp.ClientOptions{CreateMessageHandler: handler},
+	})
+}
+
+func generatedFiles(t *testing.T, res *mcp.CallToolResult) map[string]string {
+	t.Helper()
+	files := make(map[string]string)
+	for _, c := range res.Content {
+		header, body, _ := strings.Cut(c.(*mcp.TextContent).Text, "\n")
+		files[strings.TrimSuffix(strings.TrimPrefix(header, "--- FILE: "), " ---")] = body
+	}
+	return files
+}
+
+func TestHandleGenerate_DraftsWithSampling(t *testing.T) {
+	var requests []*mcp.CreateMessageParams
+	session := connectSamplingClient(t, func(_ context.Context, req *mcp.CreateMessageRequest) (*mcp.CreateMessageResult, error) {
+		requests = append(requests, req.Params)
+		return &mcp.CreateMessageResult{Model: "test", Role: "assistant", Content: &mcp.TextContent{Text: "* @acme/sampled"}}, nil
+	})
+
+	res, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "generate",
+		Arguments: map[string]any{"project_name": "sampled", "with_docker": true, "drafts": true},
+	})
+	require.NoError(t, err)
+	require.False(t, res.IsError, "unexpected tool error: %v", res.Content)
+
+	files := generatedFiles(t, res)
+	assert.Contains(t, files, "Dockerfile")
+	assert.Equal(t, "* @acme/sampled\n", files["README.md"])
+	assert.Equal(t, "* @acme/sampled\n", files[".github/CODEOWNERS"])
+	assert.Equal(t, "* @acme/sampled\n", files[".platform/PR_DESCRIPTION.md"])
+
+	require.Len(t, requests, 3)
+	for _, p := range requests {
+		assert.Equal(t, "none", p.IncludeContext)
+		assert.Contains(t, p.Messages[0].Content.(*mcp.TextContent).Text, "Project name: sampled")
+	}
+}
+
+func TestHandleGenerateWorkflows_DraftsWithSampling(t *testing.T) {
+	sampled := 0
+	session := connectSamplingClient(t, func(context.Context, *mcp.CreateMessageRequest) (*mcp.CreateMessageResult, error) {
+		sampled++
+		return &mcp.CreateMessageResult{Model: "test", Role: "assistant", Content: &mcp.TextContent{Text: "* @acme/workflows"}}, nil
+	})
+
+	res, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "generate_workflows",
+		Arguments: map[string]any{"project_name": "workflows", "drafts": true},
+	})
+	require.NoError(t, err)
+	require.False(t, res.IsError, "unexpected tool error: %v", res.Content)
+
+	files := generatedFiles(t, res)
+	assert.Contains(t, files, ".github/workflows/ci.yaml")
+	assert.Equal(t, "* @acme/workflows\n", files["README.md"])
+	assert.Equal(t, 3, sampled)
+}
+
+func TestHandleGenerate_DraftsWithoutSampling(t *testing.T) {
+	session := connectApplyClient(t)
+
+	res, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "generate",
+		Arguments: map[string]any{"project_name": "fallback", "with_actions": true, "drafts": true},
+	})
+	require.NoError(t, err)
+	require.False(t, res.IsError, "unexpected tool error: %v", res.Content)
+
+	files := generatedFiles(t, res)
+	assert.True(t, strings.HasPrefix(files["README.md"], "# fallback\n"))
+	assert.Contains(t, files[".github/CODEOWNERS"], "@your-org/fallback-maintainers")
+	assert.Contains(t, files[".platform/PR_DESCRIPTION.md"], "`.github/workflows/ci.yaml`")
+}
+
+func TestHandleGenerateBatch_RejectsDrafts(t *testing.T) {
+	_, _, err := HandleGenerateBatch(context.Background(), &mcp.CallToolRequest{}, GenerateBatchInput{
+		Components: []BatchComponent{{GenerateInput: GenerateInput{ProjectName: "api", Drafts: true}}},
+	})
+	assert.ErrorIs(t, err, errBatchDrafts)
+}
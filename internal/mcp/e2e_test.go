@@ -0,0 +1,73 @@
+package mcp
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/platform.mcp/internal/mcptest"
+)
+
+// The end-to-end tests drive the real server over an in-memory transport, so
+// tool registration, schema inference and serialization are covered. Update
+// the snapshots with: go test ./internal/mcp -run E2E -update
+
+func TestE2E_ToolsList(t *testing.T) {
+	server := NewServer("test", nil)
+	RegisterTools(server)
+	RegisterApplyTool(server)
+	session := mcptest.Connect(t, server, nil)
+
+	mcptest.AssertGolden(t, filepath.Join("testdata", "tools_list.golden.json"), mcptest.ListTools(t, session))
+}
+
+func TestE2E_ToolsCall(t *testing.T) {
+	server := NewServer("test", nil)
+	RegisterTools(server)
+	session := mcptest.Connect(t, server, nil)
+
+	tests := []struct {
+		name string
+		tool string
+		args map[string]any
+	}{
+		{
+			name: "generate_workflows",
+			tool: "generate_workflows",
+			args: map[string]any{"project_name": "e2e", "workflow_type": "typescript", "docker": true},
+		},
+		{
+			name: "generate_all",
+			tool: "generate",
+			args: map[string]any{"project_name": "e2e", "workflow_type": "go", "with_actions": true, "with_docker": true, "with_flux": true},
+		},
+		{
+			name: "generate_invalid_project_name",
+			tool: "generate",
+			args: map[string]any{"project_name": "not valid"},
+		},
+		{
+			name: "generate_batch",
+			tool: "generate_batch",
+			args: map[string]any{"components": []any{
+				map[string]any{"path": "services/api", "project_name": "api", "with_docker": true},
+				map[string]any{"path": "services/web", "project_name": "web", "with_docker": true},
+			}},
+		},
+		{
+			name: "list_templates",
+			tool: "list_templates",
+			args: map[string]any{"with_docker": true, "workflow_type": "python"},
+		},
+		{
+			name: "describe_config",
+			tool: "describe_config",
+			args: map[string]any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := mcptest.CallTool(t, session, tt.tool, tt.args)
+			mcptest.AssertGolden(t, filepath.Join("testdata", "call_"+tt.name+".golden.json"), res)
+		})
+	}
+}
@@ -5,29 +5,26 @@ import (
 	"fmt"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
-	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
 )
 
-// GenerateWorkflowsInput defines the input for the generate_workflows tool.
-type GenerateWorkflowsInput struct {
-	ProjectName  string `json:"project_name" jsonschema:"The name of the project for which to generate workflows."`
-	UseDocker    bool   `json:"docker" jsonschema:"Whether to include Docker-related workflow steps."`
-	WorkflowType string `json:"workflow_type" jsonschema:"The type of workflow to generate (go, typescript, python)."`
-}
+// GenerateWorkflowsInput is the input of the generate_workflows tool.
+//
+// Deprecated: use GenerateInput, which both tools share.
+type GenerateWorkflowsInput = GenerateInput
+
+// HandleGenerateWorkflows implements the generate_workflows MCP tool. It
+// always generates GitHub Actions workflows, plus drafts if asked.
+func HandleGenerateWorkflows(ctx context.Context, request *mcp.CallToolRequest, input GenerateInput) (*mcp.CallToolResult, any, error) {
+	cfg := input.resolve(ctx)
+	cfg.WithActions = true
 
-// HandleGenerateWorkflows implements the generate_workflows MCP tool.
-func HandleGenerateWorkflows(ctx context.Context, request *mcp.CallToolRequest, input GenerateWorkflowsInput) (*mcp.CallToolResult, any, error) {
-	cfg := scaffold.Config{
-		ProjectName:  input.ProjectName,
-		UseDocker:    input.UseDocker,
-		WorkflowType: input.WorkflowType,
-		WithDocker:   input.UseDocker,
-		WithActions:  true, // generate_workflows tool always generates workflows
+	if err := authorizeTemplates(ctx, cfg); err != nil {
+		return nil, nil, err
 	}
 
-	files, err := scaffold.Generate(cfg)
+	files, err := generateFiles(ctx, request, cfg, input.Drafts)
 	if err != nil {
-		return nil, nil, fmt.Errorf("generation failed: %w", err)
+		return nil, nil, err
 	}
 
 	var content []mcp.Content
@@ -0,0 +1,94 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	sdkauth "github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DefaultShutdownTimeout bounds how long ServeHTTP waits for in-flight requests
+// to finish once its context is cancelled.
+const DefaultShutdownTimeout = 10 * time.Second
+
+// HTTPOptions configures the streamable HTTP transport.
+type HTTPOptions struct {
+	// Addr is the TCP address to listen on, e.g. ":8080".
+	Addr string
+	// Stateless disables Mcp-Session-Id tracking so any replica can serve any request.
+	Stateless bool
+	// SessionTimeout closes sessions that have been idle for this long. Zero keeps them open.
+	SessionTimeout time.Duration
+	// ShutdownTimeout overrides DefaultShutdownTimeout when non-zero.
+	ShutdownTimeout time.Duration
+	// Verifier, when set, requires a valid bearer token on every /mcp request.
+	Verifier sdkauth.TokenVerifier
+}
+
+// NewHTTPHandler returns an http.Handler that serves the MCP streamable HTTP
+// transport at /mcp and a liveness probe at /healthz. The probe is never
+// authenticated.
+func NewHTTPHandler(server *mcp.Server, opts HTTPOptions) http.Handler {
+	var streamable http.Handler = mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server {
+		return server
+	}, &mcp.StreamableHTTPOptions{
+		Stateless:      opts.Stateless,
+		SessionTimeout: opts.SessionTimeout,
+	})
+	if opts.Verifier != nil {
+		streamable = sdkauth.RequireBearerToken(opts.Verifier, nil)(streamable)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/mcp", streamable)
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok\n"))
+	})
+	return mux
+}
+
+// ServeHTTP listens on opts.Addr and serves handler until ctx is cancelled,
+// then shuts down gracefully.
+func ServeHTTP(ctx context.Context, handler http.Handler, opts HTTPOptions) error {
+	if opts.Addr == "" {
+		return errors.New("http address is required")
+	}
+
+	srv := &http.Server{
+		Addr:              opts.Addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("http server failed: %w", err)
+	case <-ctx.Done():
+	}
+
+	timeout := opts.ShutdownTimeout
+	if timeout == 0 {
+		timeout = DefaultShutdownTimeout
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("http server shutdown failed: %w", err)
+	}
+	if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("http server failed: %w", err)
+	}
+	return nil
+}
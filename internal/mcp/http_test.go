@@ -0,0 +1,69 @@
+package mcp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHTTPHandler_Healthz(t *testing.T) {
+	server := NewServer("test", nil)
+	RegisterTools(server)
+
+	ts := httptest.NewServer(NewHTTPHandler(server, HTTPOptions{}))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/healthz")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "ok\n", string(body))
+}
+
+func TestNewHTTPHandler_StreamableSession(t *testing.T) {
+	server := NewServer("test", nil)
+	RegisterTools(server)
+
+	ts := httptest.NewServer(NewHTTPHandler(server, HTTPOptions{}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "test"}, nil)
+	session, err := client.Connect(ctx, &mcp.StreamableClientTransport{Endpoint: ts.URL + "/mcp"}, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	assert.NotEmpty(t, session.ID())
+
+	res, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "generate_workflows",
+		Arguments: map[string]any{"project_name": "http-test", "workflow_type": "go", "docker": false},
+	})
+	require.NoError(t, err)
+	assert.False(t, res.IsError)
+	assert.NotEmpty(t, res.Content)
+}
+
+func TestServeHTTP_GracefulShutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- ServeHTTP(ctx, http.NotFoundHandler(), HTTPOptions{Addr: "127.0.0.1:0"})
+	}()
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestServeHTTP_RequiresAddr(t *testing.T) {
+	err := ServeHTTP(context.Background(), http.NotFoundHandler(), HTTPOptions{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,67 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/internal/logging"
+)
+
+// loggerName is reported as the "logger" field of MCP log notifications.
+const loggerName = "platform-mcp"
+
+// logRequests returns middleware that attaches a per-session logger to the
+// request context and logs every tool call with its outcome and duration.
+// The logger writes to base and to the client's MCP log stream.
+func logRequests(base *slog.Logger) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			logger := base
+			if ss, ok := req.GetSession().(*mcp.ServerSession); ok {
+				clientHandler := logging.NewRedactingHandler(mcp.NewLoggingHandler(ss, &mcp.LoggingHandlerOptions{LoggerName: loggerName}))
+				logger = slog.New(logging.NewFanoutHandler(base.Handler(), clientHandler))
+			}
+			ctx = logging.WithLogger(ctx, logger)
+
+			if method != "tools/call" {
+				return next(ctx, method, req)
+			}
+
+			params := req.GetParams().(*mcp.CallToolParamsRaw)
+			logger = logger.With("tool", params.Name)
+			ctx = logging.WithLogger(ctx, logger)
+
+			var args map[string]any
+			_ = json.Unmarshal(params.Arguments, &args)
+			logger.Info("tool invoked", slog.Group("arguments", logging.MapAttrs(args)...))
+
+			start := time.Now()
+			res, err := next(ctx, method, req)
+			duration := time.Since(start)
+			switch {
+			case err != nil:
+				logger.Warn("tool call failed", "error", err, "duration", duration)
+			case res.(*mcp.CallToolResult).IsError:
+				logger.Warn("tool returned an error", "error", toolErrorText(res.(*mcp.CallToolResult)), "duration", duration)
+			default:
+				logger.Info("tool completed", "duration", duration)
+			}
+			return res, err
+		}
+	}
+}
+
+// toolErrorText joins the text content of an error result.
+func toolErrorText(res *mcp.CallToolResult) string {
+	var parts []string
+	for _, c := range res.Content {
+		if tc, ok := c.(*mcp.TextContent); ok {
+			parts = append(parts, tc.Text)
+		}
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,90 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/internal/logging"
+	"github.com/modelcontextprotocol/platform.mcp/internal/mcptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_LogsToClient(t *testing.T) {
+	ctx := context.Background()
+
+	var serverLogs bytes.Buffer
+	server := NewServer("test", &ServerOptions{Logger: logging.New(&serverLogs, slog.LevelInfo)})
+	RegisterTools(server)
+
+	var mu sync.Mutex
+	var messages []*mcp.LoggingMessageParams
+	clientOpts := &mcp.ClientOptions{
+		LoggingMessageHandler: func(_ context.Context, req *mcp.LoggingMessageRequest) {
+			mu.Lock()
+			defer mu.Unlock()
+			messages = append(messages, req.Params)
+		},
+	}
+
+	session := mcptest.Connect(t, server, &mcptest.Options{Client: clientOpts})
+
+	require.NoError(t, session.SetLoggingLevel(ctx, &mcp.SetLoggingLevelParams{Level: "debug"}))
+
+	res, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "generate",
+		Arguments: map[string]any{"project_name": "log-test", "with_actions": true},
+	})
+	require.NoError(t, err)
+	require.False(t, res.IsError)
+
+	// Unknown arguments are rejected by schema validation, but the call is
+	// still logged, with the secret value redacted.
+	_, err = session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "generate",
+		Arguments: map[string]any{"project_name": "log-test", "api_token": "hunter2"},
+	})
+	require.Error(t, err)
+
+	// Notifications are delivered asynchronously; wait for the failure record.
+	logged := func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		var all bytes.Buffer
+		for _, m := range messages {
+			assert.Equal(t, loggerName, m.Logger)
+			all.Write(mustMarshal(t, m.Data))
+		}
+		return all.String()
+	}
+	require.Eventually(t, func() bool {
+		return bytes.Contains([]byte(logged()), []byte("tool call failed"))
+	}, time.Second, 10*time.Millisecond)
+
+	clientLogs := logged()
+	assert.Contains(t, clientLogs, "tool invoked")
+	assert.Contains(t, clientLogs, "template rendered")
+	assert.Contains(t, clientLogs, "tool completed")
+	assert.Contains(t, clientLogs, logging.Redacted)
+	assert.NotContains(t, clientLogs, "hunter2")
+
+	// The server's own logger has its own level: info, so no debug records.
+	assert.Contains(t, serverLogs.String(), "tool invoked")
+	assert.Contains(t, serverLogs.String(), "generation complete")
+	assert.NotContains(t, serverLogs.String(), "template rendered")
+	assert.Contains(t, serverLogs.String(), "arguments.api_token="+logging.Redacted)
+	assert.NotContains(t, serverLogs.String(), "hunter2")
+}
+
+func mustMarshal(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return data
+}
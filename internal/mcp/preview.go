@@ -0,0 +1,68 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/internal/workspace"
+)
+
+// PreviewInput defines the input for the preview tool.
+type PreviewInput struct {
+	ProjectInput
+	Directory string `json:"directory" jsonschema:"Directory to compare against. Absolute, or relative to the first MCP root advertised by the client. Must lie inside one of the roots."`
+}
+
+// PreviewOutput lists the new, changed and unchanged files with their diffs.
+type PreviewOutput struct {
+	Directory string               `json:"directory"`
+	Files     []workspace.FileDiff `json:"files"`
+}
+
+// HandlePreview implements the preview MCP tool.
+func HandlePreview(ctx context.Context, request *mcp.CallToolRequest, input PreviewInput) (*mcp.CallToolResult, PreviewOutput, error) {
+	roots, err := listRootDirs(ctx, request)
+	if err != nil {
+		return nil, PreviewOutput{}, err
+	}
+	dir, err := resolveInRoots(input.Directory, roots)
+	if err != nil {
+		return nil, PreviewOutput{}, err
+	}
+
+	base, err := loadProject(dir)
+	if err != nil {
+		return nil, PreviewOutput{}, err
+	}
+	cfg, err := input.resolveFrom(ctx, base)
+	if err != nil {
+		return nil, PreviewOutput{}, err
+	}
+	if err := authorizeTemplates(ctx, cfg); err != nil {
+		return nil, PreviewOutput{}, err
+	}
+
+	files, err := generateFiles(ctx, request, cfg, input.Drafts)
+	if err != nil {
+		return nil, PreviewOutput{}, err
+	}
+
+	diffs, err := workspace.Compare(dir, files)
+	if err != nil {
+		return nil, PreviewOutput{}, fmt.Errorf("preview failed: %w", err)
+	}
+
+	var content []mcp.Content
+	for _, d := range diffs {
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "--- FILE: %s (%s) ---\n", d.Path, d.Status)
+		sb.WriteString(d.Diff)
+		content = append(content, &mcp.TextContent{Text: sb.String()})
+	}
+
+	return &mcp.CallToolResult{
+		Content: content,
+	}, PreviewOutput{Directory: dir, Files: diffs}, nil
+}
@@ -0,0 +1,115 @@
+package mcp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/internal/project"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlePreview(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	session := connectApplyClient(t, root)
+	ctx := context.Background()
+
+	args := map[string]any{
+		"project_name":  "preview-test",
+		"workflow_type": "go",
+		"with_actions":  true,
+		"directory":     root,
+	}
+
+	res, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "preview", Arguments: args})
+	require.NoError(t, err)
+	require.False(t, res.IsError, "unexpected tool error: %v", res.Content)
+	for _, f := range res.StructuredContent.(map[string]any)["files"].([]any) {
+		assert.Equal(t, "new", f.(map[string]any)["status"])
+	}
+	entries, err := os.ReadDir(root)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "preview must not write files")
+
+	// Apply, then edit one file by hand.
+	_, err = session.CallTool(ctx, &mcp.CallToolParams{Name: "apply", Arguments: args})
+	require.NoError(t, err)
+	ciPath := filepath.Join(root, ".github", "workflows", "ci.yaml")
+	require.NoError(t, os.WriteFile(ciPath, []byte("edited\n"), 0644))
+
+	res, err = session.CallTool(ctx, &mcp.CallToolParams{Name: "preview", Arguments: args})
+	require.NoError(t, err)
+	statuses := map[string]string{}
+	for _, f := range res.StructuredContent.(map[string]any)["files"].([]any) {
+		m := f.(map[string]any)
+		statuses[m["path"].(string)] = m["status"].(string)
+	}
+	assert.Equal(t, map[string]string{
+		".github/workflows/ci.yaml": "changed",
+		".github/workflows/go.yaml": "unchanged",
+	}, statuses)
+}
+
+func TestHandlePreview_ProjectFile(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, project.Save(root, scaffold.Config{
+		ProjectName:  "api",
+		WorkflowType: "python",
+		WithActions:  true,
+	}))
+	session := connectApplyClient(t, root)
+
+	// Options the caller leaves unset, the project name included, come
+	// from the project file.
+	res, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "preview", Arguments: map[string]any{
+		"with_docker": true,
+		"directory":   root,
+	}})
+	require.NoError(t, err)
+	require.False(t, res.IsError, "unexpected tool error: %v", res.Content)
+	var paths []string
+	for _, f := range res.StructuredContent.(map[string]any)["files"].([]any) {
+		paths = append(paths, f.(map[string]any)["path"].(string))
+	}
+	assert.Contains(t, paths, ".github/workflows/python.yaml")
+	assert.Contains(t, paths, "Dockerfile")
+}
+
+func TestHandlePreview_NoProjectName(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	session := connectApplyClient(t, root)
+
+	// Without a project file, the name must be given.
+	res, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "preview", Arguments: map[string]any{
+		"with_docker": true,
+		"directory":   root,
+	}})
+	require.NoError(t, err)
+	require.True(t, res.IsError)
+	assert.Contains(t, res.Content[0].(*mcp.TextContent).Text, "project_name is required")
+}
+
+func TestHandlePreview_HTTPConfinesRoots(t *testing.T) {
+	workspaceRoot, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	for _, dir := range []string{"", workspaceRoot} {
+		server := NewServer("test", &ServerOptions{WorkspaceRoot: dir, RemoteClients: true})
+		RegisterTools(server)
+		session := connectHTTPClient(t, server, "file:///")
+
+		res, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "preview", Arguments: map[string]any{
+			"project_name": "preview-test",
+			"directory":    "/etc",
+		}})
+		require.NoError(t, err)
+		assert.True(t, res.IsError, "expected the file:/// root to be refused with workspace root %q", dir)
+	}
+}
@@ -0,0 +1,31 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+)
+
+// withProgress returns a context that forwards scaffold progress to the client
+// as notifications/progress, if the client asked for them with a progress token.
+func withProgress(ctx context.Context, request *mcp.CallToolRequest) context.Context {
+	if request == nil || request.Session == nil || request.Params == nil {
+		return ctx
+	}
+	token := request.Params.GetProgressToken()
+	if token == nil {
+		return ctx
+	}
+
+	return scaffold.WithProgress(ctx, func(p scaffold.Progress) {
+		// Progress is best effort; a failed notification must not fail the tool call.
+		_ = request.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+			ProgressToken: token,
+			Message:       fmt.Sprintf("rendered %s -> %s", p.Template, p.Target),
+			Progress:      float64(p.Completed),
+			Total:         float64(p.Total),
+		})
+	})
+}
@@ -0,0 +1,67 @@
+package mcp
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/internal/mcptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleGenerate_ProgressNotifications(t *testing.T) {
+	ctx := context.Background()
+
+	server := NewServer("test", nil)
+	RegisterTools(server)
+
+	var mu sync.Mutex
+	var progress []*mcp.ProgressNotificationParams
+	clientOpts := &mcp.ClientOptions{
+		ProgressNotificationHandler: func(_ context.Context, req *mcp.ProgressNotificationClientRequest) {
+			mu.Lock()
+			defer mu.Unlock()
+			progress = append(progress, req.Params)
+		},
+	}
+
+	session := mcptest.Connect(t, server, &mcptest.Options{Client: clientOpts})
+
+	// Set the token through Meta directly: SetProgressToken does not
+	// allocate Meta when it is nil.
+	params := &mcp.CallToolParams{
+		Meta:      mcp.Meta{"progressToken": "tok-1"},
+		Name:      "generate",
+		Arguments: map[string]any{"project_name": "progress-test", "with_actions": true, "with_docker": true},
+	}
+
+	res, err := session.CallTool(ctx, params)
+	require.NoError(t, err)
+	require.False(t, res.IsError)
+
+	// Notifications are delivered asynchronously; wait until all have arrived.
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(progress) == len(res.Content)
+	}, time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	for i, p := range progress {
+		assert.Equal(t, "tok-1", p.ProgressToken)
+		assert.Equal(t, float64(i+1), p.Progress)
+		assert.Equal(t, float64(len(res.Content)), p.Total)
+	}
+}
+
+func TestHandleGenerate_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := HandleGenerate(ctx, &mcp.CallToolRequest{}, GenerateToolInput{GenerateInput: GenerateInput{ProjectName: "cancelled", WithActions: true}})
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,145 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/internal/templates"
+)
+
+// URIs of the template resources. Each manifest mapping is exposed as
+// TemplateURIPrefix followed by its name.
+const (
+	ManifestURI       = "platform-mcp://manifest.yaml"
+	TemplateURIPrefix = "platform-mcp://templates/"
+)
+
+// TemplateResources exposes the template manifest and the source of every
+// template it maps as MCP resources, and keeps them in sync with the
+// templates package when external templates are reloaded.
+type TemplateResources struct {
+	server *mcp.Server
+
+	mu       sync.Mutex
+	contents map[string]string // by URI, as of the last refresh
+}
+
+// RegisterTemplateResources adds the template resources to the server
+// instance. Clients may subscribe to them to be told when they change; see
+// Refresh.
+func RegisterTemplateResources(ctx context.Context, server *mcp.Server) (*TemplateResources, error) {
+	r := &TemplateResources{server: server, contents: make(map[string]string)}
+	if err := r.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Refresh re-reads the templates. Resources that appeared, disappeared or
+// changed are re-registered, which sends notifications/resources/list_changed
+// to every client, and subscribers of a changed resource also receive
+// notifications/resources/updated.
+func (r *TemplateResources) Refresh(ctx context.Context) error {
+	resources, contents, err := readTemplateResources()
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var removed, updated []string
+	for uri := range r.contents {
+		if _, ok := contents[uri]; !ok {
+			removed = append(removed, uri)
+		}
+	}
+	if len(removed) > 0 {
+		r.server.RemoveResources(removed...)
+	}
+	for _, res := range resources {
+		old, existed := r.contents[res.URI]
+		if existed && old == contents[res.URI] {
+			continue
+		}
+		r.server.AddResource(res, readTemplateResource)
+		if existed {
+			updated = append(updated, res.URI)
+		}
+	}
+	r.contents = contents
+
+	for _, uri := range updated {
+		if err := r.server.ResourceUpdated(ctx, &mcp.ResourceUpdatedNotificationParams{URI: uri}); err != nil {
+			return fmt.Errorf("failed to notify subscribers of %s: %w", uri, err)
+		}
+	}
+	return nil
+}
+
+// readTemplateResources lists the template resources with their current
+// content.
+func readTemplateResources() ([]*mcp.Resource, map[string]string, error) {
+	manifest, err := templates.GetManifest()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get template manifest: %w", err)
+	}
+	manifestContent, err := templates.Load("manifest.yaml")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resources := []*mcp.Resource{{
+		URI:         ManifestURI,
+		Name:        "manifest.yaml",
+		Title:       "Template manifest",
+		Description: "Maps every template to its target path and the condition under which it is generated",
+		MIMEType:    "application/yaml",
+	}}
+	contents := map[string]string{ManifestURI: manifestContent}
+	for _, t := range manifest.Templates {
+		content, err := templates.Load(t.Source)
+		if err != nil {
+			return nil, nil, err
+		}
+		uri := TemplateURIPrefix + t.Name
+		resources = append(resources, &mcp.Resource{
+			URI:         uri,
+			Name:        t.Name,
+			Description: fmt.Sprintf("Go template %s, rendered to %s when %s", t.Source, t.Target, t.Condition),
+			MIMEType:    "text/plain",
+		})
+		contents[uri] = content
+	}
+	return resources, contents, nil
+}
+
+// readTemplateResource reads a template resource. The content is resolved on
+// every read, so it reflects the active templates.
+func readTemplateResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+	_, contents, err := readTemplateResources()
+	if err != nil {
+		return nil, err
+	}
+	content, ok := contents[uri]
+	if !ok {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+	mimeType := "text/plain"
+	if uri == ManifestURI {
+		mimeType = "application/yaml"
+	}
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{{URI: uri, MIMEType: mimeType, Text: content}},
+	}, nil
+}
+
+// templateName returns the manifest name of a template resource URI.
+func templateName(uri string) (string, bool) {
+	name, ok := strings.CutPrefix(uri, TemplateURIPrefix)
+	return name, ok && name != ""
+}
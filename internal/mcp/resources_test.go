@@ -0,0 +1,113 @@
+package mcp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/internal/mcptest"
+	"github.com/modelcontextprotocol/platform.mcp/internal/templates"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateResources(t *testing.T) {
+	ctx := context.Background()
+	server := NewServer("test", nil)
+	_, err := RegisterTemplateResources(ctx, server)
+	require.NoError(t, err)
+	session := mcptest.Connect(t, server, nil)
+
+	res, err := session.ListResources(ctx, nil)
+	require.NoError(t, err)
+	manifest, err := templates.GetManifest()
+	require.NoError(t, err)
+	assert.Len(t, res.Resources, len(manifest.Templates)+1)
+
+	read, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: TemplateURIPrefix + "go-workflow"})
+	require.NoError(t, err)
+	want, err := templates.Load("go.yaml.tmpl")
+	require.NoError(t, err)
+	assert.Equal(t, want, read.Contents[0].Text)
+
+	_, err = session.ReadResource(ctx, &mcp.ReadResourceParams{URI: TemplateURIPrefix + "missing"})
+	assert.Error(t, err)
+}
+
+func TestTemplateResources_HotReload(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	write := func(content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "go.yaml.tmpl"), []byte(content), 0644))
+	}
+	write("v1 {{ .ProjectName }}\n")
+	require.NoError(t, templates.SetLayers([]string{dir}))
+	t.Cleanup(func() { templates.Activate(nil) })
+
+	server := NewServer("test", nil)
+	RegisterTools(server)
+	resources, err := RegisterTemplateResources(ctx, server)
+	require.NoError(t, err)
+	watcher := &templates.Watcher{
+		Dirs:     []string{dir},
+		OnReload: func(ctx context.Context, _ *templates.Snapshot) { require.NoError(t, resources.Refresh(ctx)) },
+	}
+
+	updated := make(chan string, 10)
+	listChanged := make(chan struct{}, 10)
+	session := mcptest.Connect(t, server, &mcptest.Options{Client: &mcp.ClientOptions{
+		ResourceUpdatedHandler: func(_ context.Context, req *mcp.ResourceUpdatedNotificationRequest) {
+			updated <- req.Params.URI
+		},
+		ResourceListChangedHandler: func(context.Context, *mcp.ResourceListChangedRequest) {
+			listChanged <- struct{}{}
+		},
+	}})
+	uri := TemplateURIPrefix + "go-workflow"
+	require.NoError(t, session.Subscribe(ctx, &mcp.SubscribeParams{URI: uri}))
+
+	generated := func() string {
+		res := mcptest.CallTool(t, session, "generate", map[string]any{"project_name": "api", "with_actions": true})
+		require.False(t, res.IsError)
+		for _, c := range res.Content {
+			if text := c.(*mcp.TextContent).Text; strings.HasPrefix(text, "--- FILE: .github/workflows/go.yaml ---") {
+				return text
+			}
+		}
+		return ""
+	}
+	assert.Contains(t, generated(), "v1 api")
+
+	// An invalid template is rejected: nothing is announced and the last
+	// good version keeps being served.
+	write("v2 {{ .ProjectName\n")
+	_, err = watcher.Check(ctx)
+	require.Error(t, err)
+	assert.Contains(t, generated(), "v1 api")
+
+	write("v3 {{ .ProjectName }}\n")
+	reloaded, err := watcher.Check(ctx)
+	require.NoError(t, err)
+	require.True(t, reloaded)
+
+	select {
+	case got := <-updated:
+		assert.Equal(t, uri, got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notifications/resources/updated received")
+	}
+	select {
+	case <-listChanged:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notifications/resources/list_changed received")
+	}
+
+	read, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: uri})
+	require.NoError(t, err)
+	assert.Equal(t, "v3 {{ .ProjectName }}\n", read.Contents[0].Text)
+	assert.Contains(t, generated(), "v3 api")
+}
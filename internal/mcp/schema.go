@@ -0,0 +1,133 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+)
+
+// deprecatedFields maps legacy input names to the field that replaces them.
+var deprecatedFields = map[string]string{
+	"use_docker": "with_docker",
+	"docker":     "with_docker",
+}
+
+// serverDefaults lists the inputs the server fills from its configured
+// defaults when a call omits them; see ServerOptions.Defaults.
+var serverDefaults = []string{"workflow_type", "org", "registry", "branch"}
+
+// InputSchema derives the JSON Schema of a tool input type from its struct
+// tags and annotates it like ConfigSchema: enums, defaults and deprecated
+// legacy fields, including in nested objects and arrays.
+func InputSchema[T any]() (*jsonschema.Schema, error) {
+	schema, err := jsonschema.For[T](nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive schema for %T: %w", *new(T), err)
+	}
+	if err := annotate(schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// ConfigSchema derives the JSON Schema of scaffold.Config from its struct
+// tags, then adds the enums and defaults the scaffold package declares.
+func ConfigSchema() (*jsonschema.Schema, error) {
+	schema, err := InputSchema[scaffold.Config]()
+	if err != nil {
+		return nil, err
+	}
+	schema.Title = "scaffold.Config"
+	schema.Description = "Options that control which files are generated. Each with_* flag enables the templates whose manifest condition it satisfies; see list_templates."
+	return schema, nil
+}
+
+// configDefaults returns the JSON encoding of scaffold.DefaultConfig, keyed
+// by field name. Nil lists and maps have no default.
+func configDefaults() (map[string]json.RawMessage, error) {
+	defaults := make(map[string]json.RawMessage)
+	v := reflect.ValueOf(scaffold.DefaultConfig())
+	for i := 0; i < v.NumField(); i++ {
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("json"), ",")
+		if k := v.Field(i).Kind(); (k == reflect.Slice || k == reflect.Map) && v.Field(i).IsNil() {
+			continue
+		}
+		raw, err := json.Marshal(v.Field(i).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal default for %s: %w", name, err)
+		}
+		defaults[name] = raw
+	}
+	return defaults, nil
+}
+
+// annotate adds enums, defaults and deprecation markers to the properties of
+// schema and of every object schema nested in it. Optional properties named
+// after a scaffold.Config field default to scaffold.DefaultConfig, unless
+// that is empty; other optional booleans default to false. Inputs in
+// serverDefaults get no default, since the server configuration decides it.
+func annotate(schema *jsonschema.Schema) error {
+	defaults, err := configDefaults()
+	if err != nil {
+		return err
+	}
+
+	var walk func(*jsonschema.Schema)
+	walk = func(s *jsonschema.Schema) {
+		if s == nil {
+			return
+		}
+		walk(s.Items)
+		for name, prop := range s.Properties {
+			walk(prop)
+
+			if name == "workflow_type" {
+				prop.Enum = nil
+				for _, t := range scaffold.WorkflowTypes {
+					prop.Enum = append(prop.Enum, t)
+				}
+				prop.Description += ` "node" is an alias for "typescript".`
+			}
+			if name == "archive" {
+				prop.Enum = nil
+				for _, f := range archiveFormats {
+					prop.Enum = append(prop.Enum, f)
+				}
+			}
+			if name == "on_conflict" {
+				prop.Enum = nil
+				for _, p := range applyPolicies {
+					prop.Enum = append(prop.Enum, string(p))
+				}
+			}
+			if replacement, ok := deprecatedFields[name]; ok {
+				prop.Deprecated = true
+				prop.Description = fmt.Sprintf("Deprecated: use %s. %s", replacement, prop.Description)
+			}
+
+			if slices.Contains(s.Required, name) {
+				continue
+			}
+			if slices.Contains(serverDefaults, name) {
+				prop.Description += " When omitted, the server's configured default applies"
+				if raw := defaults[name]; string(raw) != `""` {
+					prop.Description += ", falling back to " + string(raw)
+				}
+				prop.Description += "."
+				continue
+			}
+			if raw, ok := defaults[name]; ok && string(raw) != `""` {
+				prop.Default = raw
+			} else if prop.Type == "boolean" {
+				prop.Default = json.RawMessage("false")
+			}
+		}
+	}
+	walk(schema)
+	return nil
+}
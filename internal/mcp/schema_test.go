@@ -0,0 +1,68 @@
+package mcp
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/internal/mcptest"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateInputSchema(t *testing.T) {
+	schema, err := InputSchema[GenerateInput]()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"project_name"}, schema.Required)
+	assert.Len(t, schema.Properties["workflow_type"].Enum, len(scaffold.WorkflowTypes))
+	assert.Nil(t, schema.Properties["workflow_type"].Default)
+	assert.Nil(t, schema.Properties["org"].Default)
+	assert.JSONEq(t, `false`, string(schema.Properties["drafts"].Default))
+	for name, prop := range schema.Properties {
+		assert.NotEmpty(t, prop.Description, "property %s has no description", name)
+		_, legacy := deprecatedFields[name]
+		assert.Equal(t, legacy, prop.Deprecated, "property %s", name)
+	}
+
+	mcptest.AssertGolden(t, filepath.Join("testdata", "generate_input.schema.golden.json"), schema)
+}
+
+func TestInputSchema_Nested(t *testing.T) {
+	schema, err := InputSchema[GenerateBatchInput]()
+	require.NoError(t, err)
+
+	component := schema.Properties["components"].Items
+	require.NotNil(t, component)
+	assert.NotEmpty(t, component.Properties["workflow_type"].Enum)
+	assert.True(t, component.Properties["use_docker"].Deprecated)
+}
+
+func TestGenerateInput_LegacyDockerFields(t *testing.T) {
+	server := NewServer("test", nil)
+	RegisterTools(server)
+	session := mcptest.Connect(t, server, nil)
+
+	for _, tt := range []struct {
+		tool string
+		args map[string]any
+	}{
+		{"generate_workflows", map[string]any{"project_name": "legacy", "docker": true}},
+		{"generate_workflows", map[string]any{"project_name": "legacy", "with_docker": true}},
+		{"generate", map[string]any{"project_name": "legacy", "use_docker": true}},
+		{"generate", map[string]any{"project_name": "legacy", "docker": true}},
+	} {
+		res := mcptest.CallTool(t, session, tt.tool, tt.args)
+		require.False(t, res.IsError, "%s %v: %v", tt.tool, tt.args, res.Content)
+		assert.Contains(t, generatedFiles(t, res), "Dockerfile", "%s %v", tt.tool, tt.args)
+	}
+
+	// Enums are enforced by schema validation.
+	_, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "generate",
+		Arguments: map[string]any{"project_name": "legacy", "workflow_type": "cobol"},
+	})
+	assert.ErrorContains(t, err, "workflow_type")
+}
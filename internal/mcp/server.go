@@ -1,29 +1,105 @@
 package mcp
 
 import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/internal/auth"
+	"github.com/modelcontextprotocol/platform.mcp/internal/logging"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
 )
 
+// ServerOptions configures NewServer. The zero value is valid.
+type ServerOptions struct {
+	// Logger receives server logs. Tool calls also log to the client over the
+	// MCP logging capability, at the level the client selects with logging/setLevel.
+	// If nil, logs are only sent to the client.
+	Logger *slog.Logger
+	// Policy, when set, restricts tools and templates per authenticated
+	// identity. It requires a transport that authenticates callers; see Authorize.
+	Policy *auth.Policy
+	// Defaults fill the workflow_type, org, registry and branch of tool calls
+	// that leave them empty. Other fields are ignored.
+	Defaults scaffold.Config
+	// WorkspaceRoot confines the tools that act on the client's MCP roots to
+	// a directory of the server's filesystem: client roots outside it are
+	// ignored.
+	WorkspaceRoot string
+	// RemoteClients marks a transport, such as http, whose clients need not
+	// share the server's filesystem. Their roots are then only trusted inside
+	// WorkspaceRoot, and the tools that act on them fail if it is empty.
+	RemoteClients bool
+}
+
 // NewServer creates and initializes a new MCP server with the specified configuration.
-func NewServer(version string) *mcp.Server {
-	return mcp.NewServer(
+// A nil opts uses the defaults.
+func NewServer(version string, opts *ServerOptions) *mcp.Server {
+	if opts == nil {
+		opts = &ServerOptions{}
+	}
+	logger := opts.Logger
+	if logger == nil {
+		logger = logging.Discard()
+	}
+
+	server := mcp.NewServer(
 		&mcp.Implementation{
 			Name:    "platform-mcp",
 			Version: version,
 		},
-		nil, // Default options
+		&mcp.ServerOptions{
+			Logger: logger,
+			// Subscriptions need no bookkeeping of our own; the SDK tracks
+			// subscribers for ResourceUpdated. See TemplateResources.
+			SubscribeHandler:   func(context.Context, *mcp.SubscribeRequest) error { return nil },
+			UnsubscribeHandler: func(context.Context, *mcp.UnsubscribeRequest) error { return nil },
+		},
 	)
+
+	// Middleware added later runs first, so logging wraps authorization and
+	// sees denied calls.
+	server.AddReceivingMiddleware(withDefaults(opts.Defaults))
+	server.AddReceivingMiddleware(withWorkspace(opts.WorkspaceRoot, opts.RemoteClients))
+	if opts.Policy != nil {
+		server.AddReceivingMiddleware(Authorize(opts.Policy))
+	}
+	server.AddReceivingMiddleware(logRequests(logger))
+	return server
 }
 
-// RegisterTools adds all available tools to the server instance.
+// RegisterTools adds every catalog tool that is not opt-in to the server instance.
 func RegisterTools(server *mcp.Server) {
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "generate_workflows",
-		Description: "Generate GitHub Actions workflows for a project",
-	}, HandleGenerateWorkflows)
-
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "generate",
-		Description: "Generate project scaffolding including Actions, Docker, and Flux",
-	}, HandleGenerate)
+	for _, entry := range Catalog {
+		if !entry.OptIn {
+			entry.register(server)
+		}
+	}
+}
+
+// EnableTools adds the named catalog tools, including opt-in ones, to the
+// server instance. It fails without registering anything if a name is not
+// in the catalog.
+func EnableTools(server *mcp.Server, names []string) error {
+	known := ToolNames()
+	for _, name := range names {
+		if !slices.Contains(known, name) {
+			return fmt.Errorf("unknown tool %q", name)
+		}
+	}
+	for _, name := range names {
+		lookupTool(name).register(server)
+	}
+	return nil
+}
+
+// ToolNames returns the names of every catalog tool, in catalog order.
+func ToolNames() []string {
+	names := make([]string, len(Catalog))
+	for i, entry := range Catalog {
+		names[i] = entry.Name
+	}
+	return names
 }
@@ -5,41 +5,156 @@ import (
 	"fmt"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/internal/logging"
+	"github.com/modelcontextprotocol/platform.mcp/internal/project"
 	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
 )
 
-// GenerateInput defines the input for the generate tool.
+// GenerateInput is the input model shared by the generate and
+// generate_workflows tools, and embedded by the other tools that render a
+// scaffold. use_docker and docker are legacy names for with_docker; they are
+// still accepted but marked deprecated in the schema.
 type GenerateInput struct {
-	ProjectName  string `json:"project_name" jsonschema:"description=The name of the project"`
-	UseDocker    bool   `json:"use_docker,omitempty" jsonschema:"description=Whether to use Docker within the project templates"`
-	WorkflowType string `json:"workflow_type,omitempty" jsonschema:"description=The type of workflow (go, typescript, python)"`
-	WithActions  bool   `json:"with_actions,omitempty" jsonschema:"description=Whether to generate GitHub Actions workflows"`
-	WithDocker   bool   `json:"with_docker,omitempty" jsonschema:"description=Whether to generate Dockerfiles"`
-	WithFlux     bool   `json:"with_flux,omitempty" jsonschema:"description=Whether to generate Flux CD manifests"`
+	ProjectName  string            `json:"project_name" jsonschema:"Name of the project. Letters, digits and hyphens only; used in workflow names and build paths."`
+	WorkflowType string            `json:"workflow_type,omitempty" jsonschema:"Language of the CI workflow generated when with_actions is set."`
+	WithActions  bool              `json:"with_actions,omitempty" jsonschema:"Generate GitHub Actions workflows: a generic CI workflow plus one for workflow_type."`
+	WithDocker   bool              `json:"with_docker,omitempty" jsonschema:"Generate a Dockerfile and a Docker build workflow."`
+	WithFlux     bool              `json:"with_flux,omitempty" jsonschema:"Generate Flux CD manifests."`
+	Org          string            `json:"org,omitempty" jsonschema:"GitHub organization that owns the repository, used in Flux source URLs; myorg when empty."`
+	Registry     string            `json:"registry,omitempty" jsonschema:"Container registry host (and optional path) to push images to, e.g. ghcr.io. When empty, images are built but not pushed."`
+	Branch       string            `json:"branch,omitempty" jsonschema:"Default branch: workflows run on pushes to it and Flux tracks it. When empty, workflows run on every push and Flux tracks main."`
+	Environments []string          `json:"environments,omitempty" jsonschema:"Deployment environments, e.g. staging and production. Flux gets one Kustomization per environment, reading deploy/<environment>; when empty, a single one reads deploy/."`
+	Variables    map[string]string `json:"variables,omitempty" jsonschema:"Extra values for custom templates, available as {{ .Variables.<name> }}. Names are letters, digits and underscores, not starting with a digit."`
+	Drafts       bool              `json:"drafts,omitempty" jsonschema:"Also draft a README, a pull request description and a CODEOWNERS suggestion, using the client's LLM through MCP sampling when available and fixed templates otherwise."`
+	UseDocker    bool              `json:"use_docker,omitempty" jsonschema:"Generate the Docker templates."`
+	Docker       bool              `json:"docker,omitempty" jsonschema:"Generate the Docker templates."`
 }
 
-// HandleGenerate implements the generate MCP tool.
-func HandleGenerate(ctx context.Context, request *mcp.CallToolRequest, input GenerateInput) (*mcp.CallToolResult, any, error) {
-	cfg := scaffold.Config{
+// ProjectInput is the input model of the tools that render a scaffold for a
+// directory, which may hold a project file. It is GenerateInput with
+// project_name optional, since the project file can supply it.
+type ProjectInput struct {
+	GenerateInput
+	ProjectName string `json:"project_name,omitempty" jsonschema:"Name of the project. Letters, digits and hyphens only; used in workflow names and build paths. Required unless the project file sets it."`
+}
+
+// GenerateToolInput is the input of the generate tool: the scaffold options,
+// plus the archive format to return the files in, if any.
+type GenerateToolInput struct {
+	GenerateInput
+	Archive string `json:"archive,omitempty" jsonschema:"Return the files as a single embedded resource holding a base64-encoded tar or zip archive, keeping file modes, instead of one text block per file."`
+}
+
+// Config converts the tool input into a scaffold configuration, mapping the
+// legacy Docker fields onto with_docker.
+func (input GenerateInput) Config() scaffold.Config {
+	legacyDocker := input.UseDocker || input.Docker
+	return scaffold.Config{
 		ProjectName:  input.ProjectName,
-		UseDocker:    input.UseDocker,
+		UseDocker:    legacyDocker,
 		WorkflowType: input.WorkflowType,
 		WithActions:  input.WithActions,
-		WithDocker:   input.WithDocker,
+		WithDocker:   input.WithDocker || legacyDocker,
 		WithFlux:     input.WithFlux,
+		Org:          input.Org,
+		Registry:     input.Registry,
+		Branch:       input.Branch,
+		Environments: input.Environments,
+		Variables:    input.Variables,
+	}
+}
+
+// deprecated returns the legacy field names set in input.
+func (input GenerateInput) deprecated() []string {
+	var names []string
+	if input.UseDocker {
+		names = append(names, "use_docker")
 	}
+	if input.Docker {
+		names = append(names, "docker")
+	}
+	return names
+}
+
+// resolve is like Config but also fills the fields the caller left empty
+// from the server defaults in ctx, and logs a warning for each legacy field
+// the caller used.
+func (input GenerateInput) resolve(ctx context.Context) scaffold.Config {
+	return input.resolveFrom(ctx, scaffold.Config{})
+}
+
+// resolveFrom is like resolve, but fields the caller left empty are taken
+// from base, typically a project file, before the server defaults.
+func (input GenerateInput) resolveFrom(ctx context.Context, base scaffold.Config) scaffold.Config {
+	for _, name := range input.deprecated() {
+		logging.FromContext(ctx).Warn("deprecated input field", "field", name, "replacement", deprecatedFields[name])
+	}
+	cfg := project.Override(base, input.Config())
+	defaults := defaultsFromContext(ctx)
+	for _, f := range []struct {
+		dst *string
+		def string
+	}{
+		{&cfg.WorkflowType, defaults.WorkflowType},
+		{&cfg.Org, defaults.Org},
+		{&cfg.Registry, defaults.Registry},
+		{&cfg.Branch, defaults.Branch},
+	} {
+		if *f.dst == "" {
+			*f.dst = f.def
+		}
+	}
+	return cfg
+}
 
-	generator := scaffold.NewProjectGenerator()
-	files, err := generator.Generate(cfg)
+// resolveFrom is like GenerateInput.resolveFrom, but also validates the
+// result, so that a project name missing from both the input and base is
+// reported before anything is generated.
+func (input ProjectInput) resolveFrom(ctx context.Context, base scaffold.Config) (scaffold.Config, error) {
+	input.GenerateInput.ProjectName = input.ProjectName
+	cfg := input.GenerateInput.resolveFrom(ctx, base)
+	if cfg.ProjectName == "" {
+		return cfg, fmt.Errorf("project_name is required: set it in the call or in %s", project.FileName)
+	}
+	if err := scaffold.ValidateConfig(cfg); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+type defaultsKey struct{}
+
+// withDefaults returns middleware that makes defaults available to the tool
+// handlers; see GenerateInput.resolve.
+func withDefaults(defaults scaffold.Config) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			return next(context.WithValue(ctx, defaultsKey{}, defaults), method, req)
+		}
+	}
+}
+
+func defaultsFromContext(ctx context.Context) scaffold.Config {
+	defaults, _ := ctx.Value(defaultsKey{}).(scaffold.Config)
+	return defaults
+}
+
+// HandleGenerate implements the generate MCP tool.
+func HandleGenerate(ctx context.Context, request *mcp.CallToolRequest, input GenerateToolInput) (*mcp.CallToolResult, any, error) {
+	cfg := input.resolve(ctx)
+
+	if err := authorizeTemplates(ctx, cfg); err != nil {
+		return nil, nil, err
+	}
+
+	files, err := generateFiles(ctx, request, cfg, input.Drafts)
 	if err != nil {
-		return nil, nil, fmt.Errorf("generation failed: %w", err)
+		return nil, nil, err
 	}
 
-	var content []mcp.Content
-	for _, f := range files {
-		content = append(content, &mcp.TextContent{
-			Text: fmt.Sprintf("--- FILE: %s ---\n%s", f.Path, f.Content),
-		})
+	content, err := filesContent(files, input.Archive, cfg.ProjectName)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	return &mcp.CallToolResult{
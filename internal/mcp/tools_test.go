@@ -1,30 +1,37 @@
 package mcp
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/internal/mcptest"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHandleGenerate(t *testing.T) {
 	tests := []struct {
 		name    string
-		input   GenerateInput
+		input   GenerateToolInput
 		wantErr bool
 		check   func(*testing.T, *mcp.CallToolResult)
 	}{
 		{
 			name: "valid request with all options",
-			input: GenerateInput{
+			input: GenerateToolInput{GenerateInput: GenerateInput{
 				ProjectName:  "test-project",
 				WorkflowType: "go",
 				UseDocker:    true,
 				WithActions:  true,
 				WithDocker:   true,
 				WithFlux:     true,
-			},
+			}},
 			wantErr: false,
 			check: func(t *testing.T, res *mcp.CallToolResult) {
 				assert.False(t, res.IsError)
@@ -43,11 +50,31 @@ func TestHandleGenerate(t *testing.T) {
 		},
 		{
 			name: "missing project name",
-			input: GenerateInput{
+			input: GenerateToolInput{GenerateInput: GenerateInput{
 				ProjectName: "",
-			},
+			}},
 			wantErr: true,
 		},
+		{
+			name: "tar archive",
+			input: GenerateToolInput{
+				GenerateInput: GenerateInput{ProjectName: "test-project", WithActions: true},
+				Archive:       "tar",
+			},
+			check: func(t *testing.T, res *mcp.CallToolResult) {
+				require.Len(t, res.Content, 1)
+				embedded, ok := res.Content[0].(*mcp.EmbeddedResource)
+				require.True(t, ok, "expected an embedded resource, got %T", res.Content[0])
+				assert.Equal(t, ArchiveURIPrefix+"test-project.tar", embedded.Resource.URI)
+				assert.Equal(t, "application/x-tar", embedded.Resource.MIMEType)
+
+				tr := tar.NewReader(bytes.NewReader(embedded.Resource.Blob))
+				hdr, err := tr.Next()
+				require.NoError(t, err)
+				assert.Equal(t, ".github/workflows/ci.yaml", hdr.Name)
+				assert.Equal(t, int64(0644), hdr.Mode)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -63,3 +90,57 @@ func TestHandleGenerate(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleGenerate_ServerDefaults(t *testing.T) {
+	server := NewServer("test", &ServerOptions{
+		Defaults: scaffold.Config{Org: "acme", Branch: "trunk", WorkflowType: "python"},
+	})
+	RegisterTools(server)
+	session := mcptest.Connect(t, server, nil)
+
+	text := func(res *mcp.CallToolResult) string {
+		var sb strings.Builder
+		for _, c := range res.Content {
+			sb.WriteString(c.(*mcp.TextContent).Text)
+		}
+		return sb.String()
+	}
+
+	res := mcptest.CallTool(t, session, "generate", map[string]any{"project_name": "api", "with_actions": true, "with_flux": true})
+	require.False(t, res.IsError, text(res))
+	got := text(res)
+	assert.Contains(t, got, "https://github.com/acme/api")
+	assert.Contains(t, got, "branch: trunk")
+	assert.Contains(t, got, ".github/workflows/python.yaml")
+
+	// Values in the call take precedence over the defaults.
+	res = mcptest.CallTool(t, session, "generate", map[string]any{"project_name": "api", "with_flux": true, "org": "other", "branch": "main"})
+	require.False(t, res.IsError, text(res))
+	got = text(res)
+	assert.Contains(t, got, "https://github.com/other/api")
+	assert.Contains(t, got, "branch: main")
+}
+
+func TestHandleGenerateBatch_Archive(t *testing.T) {
+	server := NewServer("test", nil)
+	RegisterTools(server)
+	session := mcptest.Connect(t, server, nil)
+
+	res := mcptest.CallTool(t, session, "generate_batch", map[string]any{
+		"archive": "zip",
+		"components": []any{
+			map[string]any{"path": "services/api", "project_name": "api", "with_docker": true},
+		},
+	})
+	require.False(t, res.IsError)
+	require.Len(t, res.Content, 1)
+	embedded, ok := res.Content[0].(*mcp.EmbeddedResource)
+	require.True(t, ok, "expected an embedded resource, got %T", res.Content[0])
+	assert.Equal(t, "application/zip", embedded.Resource.MIMEType)
+
+	// The blob survives the base64 round trip through the transport.
+	zr, err := zip.NewReader(bytes.NewReader(embedded.Resource.Blob), int64(len(embedded.Resource.Blob)))
+	require.NoError(t, err)
+	require.NotEmpty(t, zr.File)
+	assert.Equal(t, "services/api/Dockerfile", zr.File[0].Name)
+}
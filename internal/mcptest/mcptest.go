@@ -0,0 +1,112 @@
+// Package mcptest runs an MCP server in memory and drives it with a real MCP
+// client, so tests cover tool registration, schema inference and protocol
+// serialization rather than calling handlers directly. It also compares
+// responses with golden files; run the tests with -update to rewrite them.
+package mcptest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/platform.mcp/internal/diff"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files with the actual responses")
+
+// Options configures the client side of Connect. The zero value is valid.
+type Options struct {
+	// Roots are local directories advertised to the server as file:// roots.
+	Roots []string
+	// Client configures the MCP client, e.g. to handle sampling, progress or
+	// log notifications.
+	Client *mcp.ClientOptions
+}
+
+// Connect connects a client to server over an in-memory transport pair and
+// returns the client session. Both sessions are closed when the test ends.
+func Connect(t testing.TB, server *mcp.Server, opts *Options) *mcp.ClientSession {
+	t.Helper()
+	if opts == nil {
+		opts = &Options{}
+	}
+	ctx := context.Background()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "mcptest", Version: "test"}, opts.Client)
+	for _, r := range opts.Roots {
+		client.AddRoots(&mcp.Root{URI: (&url.URL{Scheme: "file", Path: filepath.ToSlash(r)}).String()})
+	}
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+// ListTools returns the server's tools/list response, following pagination.
+func ListTools(t testing.TB, session *mcp.ClientSession) []*mcp.Tool {
+	t.Helper()
+	var tools []*mcp.Tool
+	for tool, err := range session.Tools(context.Background(), nil) {
+		if err != nil {
+			t.Fatalf("tools/list: %v", err)
+		}
+		tools = append(tools, tool)
+	}
+	return tools
+}
+
+// CallTool calls a tool and fails the test on protocol errors. Tool errors
+// are returned in the result with IsError set.
+func CallTool(t testing.TB, session *mcp.ClientSession, name string, args any) *mcp.CallToolResult {
+	t.Helper()
+	res, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: name, Arguments: args})
+	if err != nil {
+		t.Fatalf("tools/call %s: %v", name, err)
+	}
+	return res
+}
+
+// AssertGolden compares the indented JSON encoding of got with the file at
+// path, typically under testdata/. With -update it writes the file instead.
+func AssertGolden(t testing.TB, path string, got any) {
+	t.Helper()
+	data, err := json.MarshalIndent(got, "", "  ")
+	if err != nil {
+		t.Fatalf("marshal %s: %v", path, err)
+	}
+	data = append(data, '\n')
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(want, data) {
+		t.Errorf("response differs from %s (run with -update to accept it):\n%s", path, diff.Unified(path, "actual", string(want), string(data)))
+	}
+}
@@ -0,0 +1,53 @@
+package mcptest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type echoInput struct {
+	Text string `json:"text" jsonschema:"Text to echo"`
+}
+
+func newEchoServer() *mcp.Server {
+	server := mcp.NewServer(&mcp.Implementation{Name: "echo", Version: "test"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "echo", Description: "Echo the input"}, func(_ context.Context, _ *mcp.CallToolRequest, in echoInput) (*mcp.CallToolResult, any, error) {
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: in.Text}}}, nil, nil
+	})
+	return server
+}
+
+func TestConnect(t *testing.T) {
+	session := Connect(t, newEchoServer(), nil)
+
+	tools := ListTools(t, session)
+	if len(tools) != 1 || tools[0].Name != "echo" {
+		t.Fatalf("unexpected tools %+v", tools)
+	}
+
+	res := CallTool(t, session, "echo", map[string]any{"text": "hi"})
+	if res.IsError || res.Content[0].(*mcp.TextContent).Text != "hi" {
+		t.Errorf("unexpected result %+v", res)
+	}
+}
+
+func TestAssertGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "echo.golden.json")
+
+	*update = true
+	AssertGolden(t, path, map[string]string{"text": "hi"})
+	*update = false
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "{\n  \"text\": \"hi\"\n}\n" {
+		t.Errorf("unexpected golden file %q", data)
+	}
+	AssertGolden(t, path, map[string]string{"text": "hi"})
+}
@@ -0,0 +1,134 @@
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/modelcontextprotocol/platform.mcp/internal/workspace"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+)
+
+// ConfigFile records the configuration of the last generation run, relative
+// to the project directory. Check regenerates from it.
+const ConfigFile = ".platform/config.json"
+
+// Generation is the configuration of a generation run: Config for a single
+// project, or Components for a batch.
+type Generation struct {
+	Config     *scaffold.Config     `json:"config,omitempty"`
+	Components []scaffold.Component `json:"components,omitempty"`
+}
+
+// Generate renders the files g describes with the active templates.
+func (g Generation) Generate(ctx context.Context) ([]scaffold.File, error) {
+	if g.Config != nil {
+		return scaffold.NewProjectGenerator().Generate(ctx, *g.Config)
+	}
+	return scaffold.GenerateBatch(ctx, g.Components)
+}
+
+// WriteConfig writes g to the config file in dir.
+func WriteConfig(dir string, g Generation) error {
+	path := filepath.Join(dir, ConfigFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(ConfigFile), err)
+	}
+	data, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to save %s: %w", ConfigFile, err)
+	}
+	return nil
+}
+
+// ReadConfig reads the config file in dir. If there is none, the error
+// wraps os.ErrNotExist.
+func ReadConfig(dir string) (Generation, error) {
+	path := filepath.Join(dir, ConfigFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Generation{}, err
+	}
+	var g Generation
+	if err := json.Unmarshal(data, &g); err != nil {
+		return Generation{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if g.Config == nil && len(g.Components) == 0 {
+		return Generation{}, fmt.Errorf("%s has neither a config nor components", path)
+	}
+	return g, nil
+}
+
+// Drift statuses.
+const (
+	DriftMissing  = "missing"  // the generated file is not on disk
+	DriftModified = "modified" // the file was edited since it was generated
+	DriftOutdated = "outdated" // the file is as generated, but the templates changed since
+)
+
+// Drift is a generated file whose content on disk differs from what the
+// recorded configuration generates now.
+type Drift struct {
+	Path   string `json:"path"`
+	Status string `json:"status"`
+	// Diff turns the file on disk into the generated one.
+	Diff string `json:"diff"`
+}
+
+// Check regenerates the files recorded in the config file in dir, in memory,
+// and reports every one that differs from the file on disk. Files that still
+// match the lockfile differ because the templates changed, and are
+// reported as outdated, unless they were kept as they are by the last run
+// and the templates still generate the same content; the others were
+// modified by hand.
+func Check(ctx context.Context, dir string) ([]Drift, error) {
+	g, err := ReadConfig(dir)
+	if err != nil {
+		return nil, err
+	}
+	files, err := g.Generate(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate scaffold: %w", err)
+	}
+	diffs, err := workspace.Compare(dir, files)
+	if err != nil {
+		return nil, err
+	}
+
+	locked := make(map[string]LockedFile)
+	lock, err := ReadLock(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	for _, f := range lock.Files {
+		locked[f.Path] = f
+	}
+
+	var drift []Drift
+	for i, d := range diffs {
+		switch d.Status {
+		case workspace.StatusNew:
+			drift = append(drift, Drift{Path: d.Path, Status: DriftMissing, Diff: d.Diff})
+		case workspace.StatusChanged:
+			status := DriftModified
+			content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(d.Path)))
+			if err != nil {
+				return nil, err
+			}
+			if f, ok := locked[d.Path]; ok && hash(string(content)) == f.SHA256 {
+				if f.GeneratedSHA256 == hash(files[i].Content) {
+					continue
+				}
+				status = DriftOutdated
+			}
+			drift = append(drift, Drift{Path: d.Path, Status: status, Diff: d.Diff})
+		}
+	}
+	return drift, nil
+}
@@ -0,0 +1,170 @@
+package project
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/modelcontextprotocol/platform.mcp/internal/templates"
+	"github.com/modelcontextprotocol/platform.mcp/internal/workspace"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+)
+
+// LockFile is the path of the lockfile, relative to the project directory.
+const LockFile = ".platform/lock.yaml"
+
+// LockVersion is the lockfile format written by WriteLock.
+const LockVersion = 1
+
+// Lock records what a generation run produced: the resolved configuration,
+// the template behind every file and the hash of every file, so that the
+// scaffold can be audited and reproduced later.
+type Lock struct {
+	Version int `json:"version"`
+	Generation
+	Files []LockedFile `json:"files"`
+}
+
+// LockedFile is one generated file in a Lock. SHA256 is the digest of the
+// file as left on disk; GeneratedSHA256 is set when that differs from the
+// generated content, because the file was skipped or merged. The template
+// fields are empty for files not rendered from a manifest template, such as
+// drafts.
+type LockedFile struct {
+	Path            string `json:"path"`
+	SHA256          string `json:"sha256"`
+	GeneratedSHA256 string `json:"generated_sha256,omitempty"`
+	Template        string `json:"template,omitempty"`
+	Source          string `json:"source,omitempty"`
+	Layer           string `json:"layer,omitempty"`
+	TemplateSHA256  string `json:"template_sha256,omitempty"`
+}
+
+// NewLock returns a lock recording that g generated files.
+func NewLock(g Generation, files []scaffold.File) Lock {
+	lock := Lock{Version: LockVersion, Generation: g, Files: make([]LockedFile, 0, len(files))}
+	for _, f := range files {
+		locked := LockedFile{Path: f.Path, SHA256: hash(f.Content)}
+		if t := f.Template; t != nil {
+			locked.Template = t.Name
+			locked.Source = t.Source
+			locked.Layer = t.Layer
+			locked.TemplateSHA256 = t.SHA256
+		}
+		lock.Files = append(lock.Files, locked)
+	}
+	return lock
+}
+
+// Record stores the outcome of a generation run in dir: the configuration,
+// the lock, and the generated files as the base of the next upgrade.
+// results are what workspace.Write did with files; a file without a result
+// is taken to be in place as generated. Skipped and merged files are locked
+// with their content on disk. A skipped file keeps its previous base, since
+// the generated content never reached it; a merged file gets the generated
+// content, whose changes it now holds.
+func Record(dir string, g Generation, files []scaffold.File, results []workspace.Result) error {
+	actions := make(map[string]workspace.Action, len(results))
+	for _, r := range results {
+		actions[r.Path] = r.Action
+	}
+	lock := NewLock(g, files)
+	bases := make([]scaffold.File, 0, len(files))
+	for i, f := range files {
+		action := actions[f.Path]
+		if action == workspace.ActionSkipped || action == workspace.ActionMerged {
+			content, err := workspace.Dir(dir).ReadFile(f.Path)
+			if err != nil {
+				return fmt.Errorf("failed to lock %s: %w", f.Path, err)
+			}
+			if sum := hash(string(content)); sum != lock.Files[i].SHA256 {
+				lock.Files[i].GeneratedSHA256 = lock.Files[i].SHA256
+				lock.Files[i].SHA256 = sum
+			}
+			if action == workspace.ActionSkipped {
+				continue
+			}
+		}
+		bases = append(bases, f)
+	}
+
+	if err := WriteConfig(dir, g); err != nil {
+		return err
+	}
+	if err := WriteBase(dir, bases); err != nil {
+		return err
+	}
+	return WriteLock(dir, lock)
+}
+
+// WriteLock writes lock to the lockfile in dir.
+func WriteLock(dir string, lock Lock) error {
+	path := filepath.Join(dir, LockFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(LockFile), err)
+	}
+	return writeYAML(path, lock)
+}
+
+// ReadLock reads the lockfile in dir. If there is none, the error wraps
+// os.ErrNotExist.
+func ReadLock(dir string) (Lock, error) {
+	var lock Lock
+	if err := readYAML(filepath.Join(dir, LockFile), &lock); err != nil {
+		return Lock{}, err
+	}
+	if lock.Version != LockVersion {
+		return Lock{}, fmt.Errorf("unsupported %s version %d", LockFile, lock.Version)
+	}
+	return lock, nil
+}
+
+// Mismatch is a locked file that no longer matches the lock.
+type Mismatch struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// VerifyLock compares the files in dir, and the templates currently resolved
+// by the templates package, with lock. It returns one Mismatch per file
+// whose content or template changed, in lock order.
+func VerifyLock(dir string, lock Lock) ([]Mismatch, error) {
+	var mismatches []Mismatch
+	for _, f := range lock.Files {
+		path, err := workspace.Join(dir, f.Path)
+		if err != nil {
+			return nil, err
+		}
+		content, err := os.ReadFile(path)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			mismatches = append(mismatches, Mismatch{f.Path, "file is missing"})
+		case err != nil:
+			return nil, err
+		case hash(string(content)) != f.SHA256:
+			mismatches = append(mismatches, Mismatch{f.Path, "file was modified"})
+		}
+
+		if f.Source == "" {
+			continue
+		}
+		tmpl, layer, err := templates.Locate(f.Source)
+		switch {
+		case err != nil:
+			mismatches = append(mismatches, Mismatch{f.Path, fmt.Sprintf("template %s no longer exists", f.Source)})
+		case hash(tmpl) != f.TemplateSHA256:
+			reason := fmt.Sprintf("template %s changed", f.Source)
+			if layer != f.Layer {
+				reason = fmt.Sprintf("template %s changed (was %s, now %s)", f.Source, f.Layer, layer)
+			}
+			mismatches = append(mismatches, Mismatch{f.Path, reason})
+		}
+	}
+	return mismatches, nil
+}
+
+func hash(content string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
+}
@@ -0,0 +1,93 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/platform.mcp/internal/templates"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+)
+
+func TestLock(t *testing.T) {
+	dir := t.TempDir()
+	cfg := scaffold.Config{ProjectName: "api", WorkflowType: "go", WithActions: true}
+	files, err := scaffold.Generate(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		path := filepath.Join(dir, f.Path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(f.Content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lock := NewLock(Generation{Config: &cfg}, files)
+	if err := WriteLock(dir, lock); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadLock(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, lock) {
+		t.Errorf("ReadLock() = %+v, want %+v", got, lock)
+	}
+	if f := got.Files[0]; f.Layer != templates.OriginEmbedded || f.Source == "" || len(f.SHA256) != 64 || len(f.TemplateSHA256) != 64 {
+		t.Errorf("locked file = %+v", f)
+	}
+
+	if mismatches, err := VerifyLock(dir, lock); err != nil || len(mismatches) != 0 {
+		t.Fatalf("VerifyLock() = %v, %v; want no mismatches", mismatches, err)
+	}
+
+	// Edit one file by hand, delete another and override the template of a
+	// third.
+	if err := os.WriteFile(filepath.Join(dir, ".github/workflows/ci.yaml"), []byte("edited\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(dir, ".github/workflows/go.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	layer := t.TempDir()
+	if err := os.WriteFile(filepath.Join(layer, "go.yaml.tmpl"), []byte("custom\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := templates.SetLayers([]string{layer}); err != nil {
+		t.Fatal(err)
+	}
+	defer templates.Activate(nil)
+
+	mismatches, err := VerifyLock(dir, lock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reasons []string
+	for _, m := range mismatches {
+		reasons = append(reasons, m.Path+": "+m.Reason)
+	}
+	want := []string{
+		".github/workflows/ci.yaml: file was modified",
+		".github/workflows/go.yaml: file is missing",
+		".github/workflows/go.yaml: template go.yaml.tmpl changed (was embedded, now " + layer + ")",
+	}
+	if strings.Join(reasons, "\n") != strings.Join(want, "\n") {
+		t.Errorf("VerifyLock() =\n%s\nwant\n%s", strings.Join(reasons, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestReadLock_Version(t *testing.T) {
+	dir := t.TempDir()
+	if err := WriteLock(dir, Lock{Version: 99}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadLock(dir); err == nil || !strings.Contains(err.Error(), "version 99") {
+		t.Errorf("ReadLock() = %v, want an unsupported version error", err)
+	}
+}
@@ -0,0 +1,112 @@
+// Package project reads and writes the project file, .platform.yaml, which
+// records the scaffold configuration of a repository so that generation can
+// be repeated without restating every option.
+package project
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+
+	"github.com/modelcontextprotocol/platform.mcp/internal/jsonyaml"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the project file, relative to the project
+// directory.
+const FileName = ".platform.yaml"
+
+// Load reads the project file in dir. The keys are the scaffold.Config JSON
+// field names; unknown keys are an error. If there is no project file, the
+// error wraps os.ErrNotExist.
+func Load(dir string) (scaffold.Config, error) {
+	var cfg scaffold.Config
+	if err := readYAML(filepath.Join(dir, FileName), &cfg); err != nil {
+		return scaffold.Config{}, err
+	}
+	return cfg, nil
+}
+
+// Save writes cfg to the project file in dir, as YAML with the JSON field
+// names in field order.
+func Save(dir string, cfg scaffold.Config) error {
+	return writeYAML(filepath.Join(dir, FileName), cfg)
+}
+
+// readYAML decodes the YAML file at path into v, mapping it onto the JSON
+// field names of v. Unknown keys are an error.
+func readYAML(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var raw any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if raw == nil {
+		return nil
+	}
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(encoded))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeYAML writes v to path as YAML with the JSON field names, in field
+// order.
+func writeYAML(path string, v any) error {
+	var buf bytes.Buffer
+	if err := jsonyaml.Encode(&buf, v); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to save %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// Override returns base with every field that is set in cfg replaced by its
+// value in cfg. Booleans can only be switched on, and variables are merged
+// key by key.
+func Override(base, cfg scaffold.Config) scaffold.Config {
+	for _, f := range []struct {
+		dst *string
+		v   string
+	}{
+		{&base.ProjectName, cfg.ProjectName},
+		{&base.WorkflowType, cfg.WorkflowType},
+		{&base.Org, cfg.Org},
+		{&base.Registry, cfg.Registry},
+		{&base.Branch, cfg.Branch},
+	} {
+		if f.v != "" {
+			*f.dst = f.v
+		}
+	}
+	base.UseDocker = base.UseDocker || cfg.UseDocker
+	base.WithActions = base.WithActions || cfg.WithActions
+	base.WithDocker = base.WithDocker || cfg.WithDocker
+	base.WithFlux = base.WithFlux || cfg.WithFlux
+	if len(cfg.Environments) > 0 {
+		base.Environments = cfg.Environments
+	}
+	if len(cfg.Variables) > 0 {
+		vars := make(map[string]string, len(base.Variables)+len(cfg.Variables))
+		maps.Copy(vars, base.Variables)
+		maps.Copy(vars, cfg.Variables)
+		base.Variables = vars
+	}
+	return base
+}
@@ -0,0 +1,86 @@
+package project
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+)
+
+func TestSaveLoad(t *testing.T) {
+	dir := t.TempDir()
+	want := scaffold.Config{
+		ProjectName:  "api",
+		WorkflowType: "go",
+		WithActions:  true,
+		WithFlux:     true,
+		Environments: []string{"staging", "production"},
+		Variables:    map[string]string{"team": "payments"},
+	}
+	if err := Save(dir, want); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, FileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "project_name: api\n") || !strings.Contains(string(data), "\n  - staging\n") {
+		t.Errorf("unexpected %s:\n%s", FileName, data)
+	}
+
+	got, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Load() = %+v, want %+v", got, want)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	if _, err := Load(t.TempDir()); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Load(no file) = %v, want os.ErrNotExist", err)
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte("project_name: api\nwith_flxu: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(dir); err == nil || !strings.Contains(err.Error(), "with_flxu") {
+		t.Errorf("Load(unknown key) = %v, want it to name the key", err)
+	}
+}
+
+func TestOverride(t *testing.T) {
+	base := scaffold.Config{
+		ProjectName:  "api",
+		WorkflowType: "python",
+		WithFlux:     true,
+		Environments: []string{"production"},
+		Variables:    map[string]string{"team": "payments", "tier": "1"},
+	}
+	got := Override(base, scaffold.Config{
+		WorkflowType: "go",
+		WithActions:  true,
+		Variables:    map[string]string{"tier": "2"},
+	})
+	want := scaffold.Config{
+		ProjectName:  "api",
+		WorkflowType: "go",
+		WithActions:  true,
+		WithFlux:     true,
+		Environments: []string{"production"},
+		Variables:    map[string]string{"team": "payments", "tier": "2"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Override() = %+v, want %+v", got, want)
+	}
+	if base.Variables["tier"] != "1" {
+		t.Error("Override() modified the base variables")
+	}
+}
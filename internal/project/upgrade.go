@@ -0,0 +1,87 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/modelcontextprotocol/platform.mcp/internal/workspace"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+)
+
+// BaseDir holds a copy of the files as last generated, relative to the
+// project directory. Upgrade merges template changes against it.
+const BaseDir = ".platform/base"
+
+// WriteBase stores files as the base of the next upgrade.
+func WriteBase(dir string, files []scaffold.File) error {
+	for _, f := range files {
+		path, err := workspace.Join(filepath.Join(dir, BaseDir), f.Path)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to store base of %s: %w", f.Path, err)
+		}
+		if err := os.WriteFile(path, []byte(f.Content), 0644); err != nil {
+			return fmt.Errorf("failed to store base of %s: %w", f.Path, err)
+		}
+	}
+	return nil
+}
+
+// Upgrade statuses. A file that made the upgrade fail, or that was rolled
+// back because another one did, has the workspace.Action as its status.
+const (
+	UpgradeCreated   = "created"   // the file did not exist
+	UpgradeUpdated   = "updated"   // the file had no local edits and was replaced
+	UpgradeMerged    = "merged"    // template changes were merged into local edits
+	UpgradeConflict  = "conflict"  // merged with conflict markers
+	UpgradeUnchanged = "unchanged" // the template output did not change the file
+)
+
+// UpgradeResult reports what Upgrade did with one file.
+type UpgradeResult struct {
+	Path      string `json:"path"`
+	Status    string `json:"status"`
+	Conflicts int    `json:"conflicts,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Upgrade brings the files in dir up to date with files, the output of the
+// new templates for g, and records them. Each file is three-way merged: the
+// changes from its stored base to the new output are applied to the file on
+// disk, so local edits are kept, and regions changed on both sides are
+// marked as conflicts. A file without a stored base is merged as if the
+// base were empty. The files are written with workspace.Write, so either
+// all of them are upgraded or none is, and the record is only refreshed
+// once they are.
+func Upgrade(dir string, g Generation, files []scaffold.File) ([]UpgradeResult, error) {
+	written, err := workspace.Write(workspace.Dir(dir), files, workspace.Options{
+		Policy: workspace.PolicyMerge,
+		Base:   workspace.Dir(filepath.Join(dir, BaseDir)),
+	})
+	results := make([]UpgradeResult, 0, len(written))
+	for i, r := range written {
+		result := UpgradeResult{Path: r.Path, Status: string(r.Action), Conflicts: r.Conflicts, Error: r.Error}
+		switch {
+		case r.Action == workspace.ActionCreated:
+			result.Status = UpgradeCreated
+		case r.Action == workspace.ActionUnchanged:
+			result.Status = UpgradeUnchanged
+		case r.Action == workspace.ActionMerged && r.Conflicts > 0:
+			result.Status = UpgradeConflict
+		case r.Action == workspace.ActionMerged:
+			// Without local edits, the merge is the new output.
+			result.Status = UpgradeMerged
+			if content, err := workspace.Dir(dir).ReadFile(r.Path); err == nil && string(content) == files[i].Content {
+				result.Status = UpgradeUpdated
+			}
+		}
+		results = append(results, result)
+	}
+	if err != nil {
+		return results, err
+	}
+	return results, Record(dir, g, files, written)
+}
@@ -0,0 +1,135 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/platform.mcp/internal/templates"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+)
+
+func TestUpgrade(t *testing.T) {
+	dir, layer := t.TempDir(), t.TempDir()
+	cfg := scaffold.Config{ProjectName: "api", WorkflowType: "go", WithActions: true}
+	generate := func(tmpl string) []scaffold.File {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(layer, "go.yaml.tmpl"), []byte(tmpl), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := templates.SetLayers([]string{layer}); err != nil {
+			t.Fatal(err)
+		}
+		files, err := scaffold.Generate(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return files
+	}
+	defer templates.Activate(nil)
+	goPath := filepath.Join(dir, ".github", "workflows", "go.yaml")
+	ciPath := filepath.Join(dir, ".github", "workflows", "ci.yaml")
+	read := func(path string) string {
+		t.Helper()
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	// First run: write the files and record them.
+	files := generate("name: {{ .ProjectName }}\nstep: one\ncache: on\nlint: off\n")
+	if _, err := Upgrade(dir, Generation{Config: &cfg}, files); err != nil {
+		t.Fatal(err)
+	}
+
+	// A local edit, then a template change to a line that is not next to it.
+	if err := os.WriteFile(goPath, []byte("name: api\nstep: one\ncache: on\nlint: on\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	files = generate("name: {{ .ProjectName }}\nstep: two\ncache: on\nlint: off\n")
+	results, err := Upgrade(dir, Generation{Config: &cfg}, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statuses := map[string]string{}
+	for _, r := range results {
+		statuses[r.Path] = r.Status
+	}
+	if statuses[".github/workflows/go.yaml"] != UpgradeMerged || statuses[".github/workflows/ci.yaml"] != UpgradeUnchanged {
+		t.Errorf("statuses = %v", statuses)
+	}
+	if got := read(goPath); got != "name: api\nstep: two\ncache: on\nlint: on\n" {
+		t.Errorf("merged go.yaml = %q", got)
+	}
+
+	// The template changes the line edited locally: a conflict, and the
+	// base and lock are refreshed so the next upgrade starts from the new output.
+	files = generate("name: {{ .ProjectName }}\nstep: two\ncache: on\nlint: strict\n")
+	results, err = Upgrade(dir, Generation{Config: &cfg}, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[1].Path != ".github/workflows/go.yaml" || results[1].Status != UpgradeConflict || results[1].Conflicts != 1 {
+		t.Errorf("results = %+v", results)
+	}
+	if got := read(goPath); !strings.Contains(got, "<<<<<<< local\nlint: on\n||||||| base\nlint: off\n=======\nlint: strict\n>>>>>>> template\n") {
+		t.Errorf("conflicted go.yaml = %q", got)
+	}
+	if got := read(filepath.Join(dir, BaseDir, ".github", "workflows", "go.yaml")); got != "name: api\nstep: two\ncache: on\nlint: strict\n" {
+		t.Errorf("stored base = %q", got)
+	}
+	lock, err := ReadLock(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mismatches, err := VerifyLock(dir, lock); err != nil || len(mismatches) != 0 {
+		t.Errorf("VerifyLock() after upgrade = %v, %v", mismatches, err)
+	}
+
+	// A file without local edits is replaced, a deleted one recreated.
+	if err := os.WriteFile(goPath, []byte(files[1].Content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(ciPath); err != nil {
+		t.Fatal(err)
+	}
+	files = generate("name: {{ .ProjectName }}\nstep: three\ncache: on\nlint: strict\n")
+	results, err = Upgrade(dir, Generation{Config: &cfg}, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Status != UpgradeCreated || results[1].Status != UpgradeUpdated {
+		t.Errorf("results = %+v", results)
+	}
+	if got := read(goPath); got != files[1].Content {
+		t.Errorf("updated go.yaml = %q", got)
+	}
+
+	// A file that cannot be written rolls the whole upgrade back, leaving
+	// the lock as it was.
+	lockBefore := read(filepath.Join(dir, LockFile))
+	if err := os.Remove(ciPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(ciPath, "blocked"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	updated := read(goPath)
+	files = generate("name: {{ .ProjectName }}\nstep: four\ncache: on\nlint: strict\n")
+	results, err = Upgrade(dir, Generation{Config: &cfg}, files)
+	if err == nil {
+		t.Fatal("Upgrade() succeeded with a directory in the way")
+	}
+	if results[0].Status != "failed" || results[1].Status != "rolled-back" {
+		t.Errorf("results = %+v", results)
+	}
+	if got := read(goPath); got != updated {
+		t.Errorf("go.yaml after a failed upgrade = %q, want %q", got, updated)
+	}
+	if got := read(filepath.Join(dir, LockFile)); got != lockBefore {
+		t.Errorf("lock after a failed upgrade = %q", got)
+	}
+}
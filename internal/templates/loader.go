@@ -20,21 +20,40 @@ func SetBaseDir(path string) {
 	BaseDir = path
 }
 
-// Load reads a template file from the external directory (if set) or the embedded filesystem.
+// OriginEmbedded is the layer Locate reports for the embedded templates.
+const OriginEmbedded = "embedded"
+
+// Load reads a template file from BaseDir, the active layers (see SetLayers)
+// or the embedded filesystem, whichever has it first.
 func Load(name string) (string, error) {
+	content, _, err := Locate(name)
+	return content, err
+}
+
+// Locate is like Load but also reports the layer the template came from:
+// the external directory it was read from, or OriginEmbedded.
+func Locate(name string) (content string, layer string, err error) {
+	return Active().Locate(name)
+}
+
+// Locate is like the package-level Locate, but reads the layers of s rather
+// than the active ones. A nil s has no layers.
+func (s *Snapshot) Locate(name string) (content string, layer string, err error) {
 	if BaseDir != "" {
-		externalPath := filepath.Join(BaseDir, name)
-		content, err := os.ReadFile(externalPath)
+		content, err := os.ReadFile(filepath.Join(BaseDir, name))
 		if err == nil {
-			return string(content), nil
+			return string(content), BaseDir, nil
 		}
 	}
+	if content, dir, ok := s.locate(name); ok {
+		return content, dir, nil
+	}
 
-	content, err := FS.ReadFile(name)
+	embedded, err := FS.ReadFile(name)
 	if err != nil {
-		return "", fmt.Errorf("failed to load template %s: %w", name, err)
+		return "", "", fmt.Errorf("failed to load template %s: %w", name, err)
 	}
-	return string(content), nil
+	return string(embedded), OriginEmbedded, nil
 }
 
 // Manifest represents the template manifest structure.
@@ -50,13 +69,25 @@ type TemplateMapping struct {
 	Condition string `yaml:"condition" json:"condition"`
 }
 
-// GetManifest parses the template manifest from the external directory or embedded filesystem.
+// GetManifest parses the template manifest from BaseDir, the active layers
+// or the embedded filesystem, whichever has one first.
 func GetManifest() (*Manifest, error) {
+	return Active().Manifest()
+}
+
+// Manifest is like GetManifest, but reads the layers of s rather than the
+// active ones. A nil s has no layers.
+func (s *Snapshot) Manifest() (*Manifest, error) {
 	var content []byte
 	var err error
 
 	if BaseDir != "" {
-		content, err = os.ReadFile(filepath.Join(BaseDir, "manifest.yaml"))
+		content, _ = os.ReadFile(filepath.Join(BaseDir, "manifest.yaml"))
+	}
+	if content == nil {
+		if manifest, ok := s.file("manifest.yaml"); ok {
+			content = []byte(manifest)
+		}
 	}
 
 	if content == nil {
@@ -66,6 +97,10 @@ func GetManifest() (*Manifest, error) {
 		}
 	}
 
+	return parseManifest(content)
+}
+
+func parseManifest(content []byte) (*Manifest, error) {
 	var m Manifest
 	if err := yaml.Unmarshal(content, &m); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal manifest: %w", err)
@@ -120,3 +120,63 @@ func TestExternalLoading(t *testing.T) {
 		t.Error("Expected embedded content, got empty")
 	}
 }
+
+func TestLayers(t *testing.T) {
+	team, org := t.TempDir(), t.TempDir()
+	write := func(dir, name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(team, "go.yaml.tmpl", "team go")
+	write(org, "go.yaml.tmpl", "org go")
+	write(org, "python.yaml.tmpl", "org python")
+	write(org, "manifest.yaml", "templates:\n  - name: org-only\n    source: python.yaml.tmpl\n    target: ci.yml\n    condition: always\n")
+
+	if err := SetLayers([]string{team, org}); err != nil {
+		t.Fatal(err)
+	}
+	defer Activate(nil)
+
+	for name, want := range map[string]string{"go.yaml.tmpl": "team go", "python.yaml.tmpl": "org python"} {
+		if got, err := Load(name); err != nil || got != want {
+			t.Errorf("Load(%s) = %q, %v; want %q", name, got, err, want)
+		}
+	}
+	for name, want := range map[string]string{"go.yaml.tmpl": team, "python.yaml.tmpl": org, "typescript.yaml.tmpl": OriginEmbedded} {
+		if _, layer, err := Locate(name); err != nil || layer != want {
+			t.Errorf("Locate(%s) layer = %s, err = %v; want %s", name, layer, err, want)
+		}
+	}
+
+	m, err := GetManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Templates) != 1 || m.Templates[0].Name != "org-only" {
+		t.Errorf("manifest = %+v, want the org layer's", m.Templates)
+	}
+}
+
+func TestSnapshot_OutlivesActivation(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.yaml.tmpl"), []byte("team go"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetLayers([]string{dir}); err != nil {
+		t.Fatal(err)
+	}
+	s := Active()
+	Activate(nil)
+
+	// A snapshot taken before a reload keeps serving its own layers.
+	if content, layer, err := s.Locate("go.yaml.tmpl"); err != nil || content != "team go" || layer != dir {
+		t.Errorf("Locate = %q (%s), %v; want the snapshot's template", content, layer, err)
+	}
+	if content, _ := Load("go.yaml.tmpl"); content == "team go" {
+		t.Error("Load served a deactivated layer")
+	}
+	if m, err := s.Manifest(); err != nil || len(m.Templates) == 0 {
+		t.Errorf("Manifest = %v, %v; want the embedded manifest", m, err)
+	}
+}
@@ -0,0 +1,180 @@
+package templates
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"text/template"
+)
+
+// Snapshot is an in-memory copy of the external template layers. Templates
+// are served from the active snapshot, so files that change on disk only take
+// effect once a new snapshot has been validated and activated.
+type Snapshot struct {
+	// Dirs are the layers the snapshot was read from, highest precedence first.
+	Dirs []string
+	// Version is a digest of every file in the snapshot.
+	Version string
+
+	files  map[string]string
+	layers map[string]string // directory each file was read from
+}
+
+var active atomic.Pointer[Snapshot]
+
+// Active returns the active snapshot, or nil if no layers are set.
+func Active() *Snapshot {
+	return active.Load()
+}
+
+// Activate makes s the source of external templates. A nil s removes the
+// layers.
+func Activate(s *Snapshot) {
+	active.Store(s)
+}
+
+// SetLayers reads the external template directories dirs, validates them and
+// activates them. dirs are searched in order after BaseDir and before the
+// embedded filesystem, so a team directory can override an organization-wide
+// one. On error the active layers are left unchanged.
+func SetLayers(dirs []string) error {
+	if len(dirs) == 0 {
+		Activate(nil)
+		return nil
+	}
+	s, err := ReadSnapshot(dirs)
+	if err != nil {
+		return err
+	}
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	Activate(s)
+	return nil
+}
+
+// ReadSnapshot reads every file below dirs. When several directories have a
+// file with the same relative path, the first one wins.
+func ReadSnapshot(dirs []string) (*Snapshot, error) {
+	s := &Snapshot{Dirs: dirs, files: make(map[string]string), layers: make(map[string]string)}
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			name := filepath.ToSlash(rel)
+			if _, ok := s.files[name]; ok {
+				return nil
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			s.files[name] = string(content)
+			s.layers[name] = dir
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template directory %s: %w", dir, err)
+		}
+	}
+
+	h := sha256.New()
+	for _, name := range s.Names() {
+		fmt.Fprintf(h, "%s\x00%d\x00%s", name, len(s.files[name]), s.files[name])
+	}
+	s.Version = hex.EncodeToString(h.Sum(nil))[:12]
+	return s, nil
+}
+
+// Names returns the paths of the files in the snapshot, sorted.
+func (s *Snapshot) Names() []string {
+	if s == nil {
+		return nil
+	}
+	names := make([]string, 0, len(s.files))
+	for name := range s.files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func (s *Snapshot) file(name string) (string, bool) {
+	if s == nil {
+		return "", false
+	}
+	content, ok := s.files[name]
+	return content, ok
+}
+
+// Validate checks that the snapshot, combined with the embedded templates it
+// overrides, is usable: the manifest parses, every mapping is complete and
+// uniquely named, every source exists and every template parses.
+func (s *Snapshot) Validate() error {
+	content, ok := s.file("manifest.yaml")
+	if !ok {
+		embedded, err := FS.ReadFile("manifest.yaml")
+		if err != nil {
+			return fmt.Errorf("failed to read manifest.yaml: %w", err)
+		}
+		content = string(embedded)
+	}
+	m, err := parseManifest([]byte(content))
+	if err != nil {
+		return err
+	}
+	if len(m.Templates) == 0 {
+		return errors.New("manifest.yaml declares no templates")
+	}
+
+	var errs []error
+	seen := make(map[string]bool)
+	for i, t := range m.Templates {
+		if t.Name == "" || t.Source == "" || t.Target == "" {
+			errs = append(errs, fmt.Errorf("manifest.yaml: template %d needs a name, source and target", i+1))
+			continue
+		}
+		if seen[t.Name] {
+			errs = append(errs, fmt.Errorf("manifest.yaml: duplicate template name %q", t.Name))
+		}
+		seen[t.Name] = true
+		if _, ok := s.file(t.Source); !ok {
+			if _, err := FS.ReadFile(t.Source); err != nil {
+				errs = append(errs, fmt.Errorf("manifest.yaml: template %q: source %s not found", t.Name, t.Source))
+			}
+		}
+	}
+	for _, name := range s.Names() {
+		if !strings.HasSuffix(name, ".tmpl") {
+			continue
+		}
+		if _, err := template.New(name).Parse(s.files[name]); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// locate is like file but also returns the directory the file was read from.
+func (s *Snapshot) locate(name string) (string, string, bool) {
+	content, ok := s.file(name)
+	if !ok {
+		return "", "", false
+	}
+	return content, s.layers[name], true
+}
@@ -0,0 +1,80 @@
+package templates
+
+import (
+	"context"
+	"time"
+
+	"github.com/modelcontextprotocol/platform.mcp/internal/logging"
+)
+
+// Watcher polls the external template layers and activates a new snapshot
+// when their content changes. A snapshot that fails validation is rejected
+// and the last good one stays active.
+type Watcher struct {
+	// Dirs are the layers, highest precedence first.
+	Dirs []string
+	// Interval is the time between polls.
+	Interval time.Duration
+	// OnReload, if set, is called after a new snapshot has been activated.
+	OnReload func(ctx context.Context, s *Snapshot)
+
+	// rejected and failure remember the last problem reported, so it is
+	// logged once rather than on every poll.
+	rejected string
+	failure  string
+}
+
+// Run polls until ctx is cancelled.
+func (w *Watcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, _ = w.Check(ctx)
+		}
+	}
+}
+
+// Check reads the layers once and activates them if they changed and are
+// valid. It reports whether a new snapshot was activated. Check must not be
+// called concurrently with itself or Run.
+func (w *Watcher) Check(ctx context.Context) (bool, error) {
+	logger := logging.FromContext(ctx)
+
+	s, err := ReadSnapshot(w.Dirs)
+	if err != nil {
+		if err.Error() != w.failure {
+			w.failure = err.Error()
+			logger.Warn("template reload failed, keeping the last good version", "error", err)
+		}
+		return false, err
+	}
+	w.failure = ""
+
+	current := Active()
+	if current != nil && current.Version == s.Version {
+		return false, nil
+	}
+	if err := s.Validate(); err != nil {
+		if s.Version != w.rejected {
+			w.rejected = s.Version
+			logger.Warn("template reload rejected, keeping the last good version", "version", s.Version, "error", err)
+		}
+		return false, err
+	}
+	w.rejected = ""
+
+	Activate(s)
+	previous := ""
+	if current != nil {
+		previous = current.Version
+	}
+	logger.Info("templates reloaded", "version", s.Version, "previous", previous, "files", len(s.files))
+	if w.OnReload != nil {
+		w.OnReload(ctx, s)
+	}
+	return true, nil
+}
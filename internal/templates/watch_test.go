@@ -0,0 +1,96 @@
+package templates
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSnapshot_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{"override only", map[string]string{"go.yaml.tmpl": "name: {{ .ProjectName }}"}, ""},
+		{"template syntax", map[string]string{"go.yaml.tmpl": "name: {{ .ProjectName"}, "go.yaml.tmpl"},
+		{"manifest syntax", map[string]string{"manifest.yaml": "templates: ["}, "unmarshal manifest"},
+		{"empty manifest", map[string]string{"manifest.yaml": "templates: []\n"}, "declares no templates"},
+		{"missing source", map[string]string{"manifest.yaml": "templates:\n  - name: x\n    source: x.tmpl\n    target: x\n    condition: always\n"}, "source x.tmpl not found"},
+		{"duplicate name", map[string]string{"manifest.yaml": "templates:\n  - {name: x, source: go.yaml.tmpl, target: a}\n  - {name: x, source: go.yaml.tmpl, target: b}\n"}, "duplicate template name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				writeTemplate(t, dir, name, content)
+			}
+			s, err := ReadSnapshot([]string{dir})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = s.Validate()
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestWatcher_Check(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, "go.yaml.tmpl", "v1 {{ .ProjectName }}")
+	defer Activate(nil)
+
+	var reloads int
+	w := &Watcher{Dirs: []string{dir}, OnReload: func(context.Context, *Snapshot) { reloads++ }}
+	ctx := context.Background()
+
+	check := func(wantReload bool, wantErr bool, wantContent string) {
+		t.Helper()
+		reloaded, err := w.Check(ctx)
+		if reloaded != wantReload || (err != nil) != wantErr {
+			t.Fatalf("Check() = %t, %v; want %t, error %t", reloaded, err, wantReload, wantErr)
+		}
+		if got, _ := Load("go.yaml.tmpl"); got != wantContent {
+			t.Errorf("go.yaml.tmpl = %q, want %q", got, wantContent)
+		}
+	}
+
+	check(true, false, "v1 {{ .ProjectName }}")
+	check(false, false, "v1 {{ .ProjectName }}")
+
+	// An invalid change is rejected and the last good version stays active.
+	writeTemplate(t, dir, "go.yaml.tmpl", "v2 {{ .ProjectName")
+	check(false, true, "v1 {{ .ProjectName }}")
+
+	writeTemplate(t, dir, "go.yaml.tmpl", "v3 {{ .ProjectName }}")
+	check(true, false, "v3 {{ .ProjectName }}")
+
+	// Removing the override falls back to the embedded template.
+	if err := os.Remove(filepath.Join(dir, "go.yaml.tmpl")); err != nil {
+		t.Fatal(err)
+	}
+	embedded, _ := FS.ReadFile("go.yaml.tmpl")
+	check(true, false, string(embedded))
+
+	if reloads != 3 {
+		t.Errorf("OnReload called %d times, want 3", reloads)
+	}
+}
+
+func writeTemplate(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,59 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/modelcontextprotocol/platform.mcp/internal/diff"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+)
+
+// Status classifies a generated file against what is on disk.
+type Status string
+
+const (
+	StatusNew       Status = "new"
+	StatusChanged   Status = "changed"
+	StatusUnchanged Status = "unchanged"
+)
+
+// FileDiff compares one generated file with the file currently on disk.
+type FileDiff struct {
+	Path   string `json:"path"`
+	Status Status `json:"status"`
+	Diff   string `json:"diff,omitempty"`
+}
+
+// Compare reads the files below dir that files would write and returns a
+// unified diff for each one. Nothing is written.
+func Compare(dir string, files []scaffold.File) ([]FileDiff, error) {
+	diffs := make([]FileDiff, 0, len(files))
+	for _, file := range files {
+		targetPath, err := Join(dir, file.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		current, err := os.ReadFile(targetPath)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			diffs = append(diffs, FileDiff{
+				Path:   file.Path,
+				Status: StatusNew,
+				Diff:   diff.Unified("/dev/null", "b/"+file.Path, "", file.Content),
+			})
+		case err != nil:
+			return nil, fmt.Errorf("failed to read %s: %w", targetPath, err)
+		case string(current) == file.Content:
+			diffs = append(diffs, FileDiff{Path: file.Path, Status: StatusUnchanged})
+		default:
+			diffs = append(diffs, FileDiff{
+				Path:   file.Path,
+				Status: StatusChanged,
+				Diff:   diff.Unified("a/"+file.Path, "b/"+file.Path, string(current), file.Content),
+			})
+		}
+	}
+	return diffs, nil
+}
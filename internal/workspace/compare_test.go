@@ -0,0 +1,51 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+)
+
+func TestCompare(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "same.txt"), []byte("same\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "changed.txt"), []byte("old\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	files := []scaffold.File{
+		{Path: "same.txt", Content: "same\n"},
+		{Path: "changed.txt", Content: "new\n"},
+		{Path: "nested/new.txt", Content: "fresh\n"},
+	}
+
+	diffs, err := Compare(dir, files)
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+
+	want := []Status{StatusUnchanged, StatusChanged, StatusNew}
+	for i, d := range diffs {
+		if d.Status != want[i] {
+			t.Errorf("%s: status = %s, want %s", d.Path, d.Status, want[i])
+		}
+	}
+	if diffs[0].Diff != "" {
+		t.Errorf("unchanged file should have no diff, got %q", diffs[0].Diff)
+	}
+	if !strings.Contains(diffs[1].Diff, "-old\n+new\n") {
+		t.Errorf("unexpected diff for changed file:\n%s", diffs[1].Diff)
+	}
+	if !strings.HasPrefix(diffs[2].Diff, "--- /dev/null\n+++ b/nested/new.txt\n") {
+		t.Errorf("unexpected diff for new file:\n%s", diffs[2].Diff)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "nested")); !os.IsNotExist(err) {
+		t.Error("Compare must not write to disk")
+	}
+}
@@ -0,0 +1,188 @@
+package workspace
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"slices"
+	"strings"
+)
+
+// Git is a Writer for the working tree of a git repository. Files are
+// written like Dir; Close stages them with git add.
+type Git struct {
+	Dir
+	written []string
+}
+
+// NewGit returns a Git writing into dir, which must lie in a git working
+// tree.
+func NewGit(dir string) (*Git, error) {
+	if _, err := git(dir, "rev-parse", "--is-inside-work-tree"); err != nil {
+		return nil, fmt.Errorf("%s is not in a git working tree: %w", dir, err)
+	}
+	return &Git{Dir: Dir(dir)}, nil
+}
+
+// WriteFile implements Writer.
+func (g *Git) WriteFile(path string, content []byte, mode fs.FileMode) error {
+	if err := g.Dir.WriteFile(path, content, mode); err != nil {
+		return err
+	}
+	g.written = append(g.written, path)
+	return nil
+}
+
+// Stage implements Stager, recording the file as written once it is
+// committed.
+func (g *Git) Stage(path string, content []byte, mode fs.FileMode) (Staged, error) {
+	s, err := g.Dir.Stage(path, content, mode)
+	if err != nil {
+		return nil, err
+	}
+	return &gitStaged{Staged: s, git: g, path: path}, nil
+}
+
+type gitStaged struct {
+	Staged
+	git  *Git
+	path string
+}
+
+func (s *gitStaged) Commit() error {
+	if err := s.Staged.Commit(); err != nil {
+		return err
+	}
+	s.git.written = append(s.git.written, s.path)
+	return nil
+}
+
+func (s *gitStaged) Rollback() error {
+	s.git.written = slices.DeleteFunc(s.git.written, func(p string) bool { return p == s.path })
+	return s.Staged.Rollback()
+}
+
+// Written returns the paths written so far.
+func (g *Git) Written() []string {
+	return append([]string(nil), g.written...)
+}
+
+// Close stages the written files, and only those.
+func (g *Git) Close() error {
+	return g.Add(g.written...)
+}
+
+// Add stages paths, relative to the sink, leaving out those git ignores.
+// A directory stages every file below it.
+func (g *Git) Add(paths ...string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	// check-ignore exits with 1 when no path is ignored.
+	out, err := git(string(g.Dir), append([]string{"check-ignore", "--"}, paths...)...)
+	var exitErr *exec.ExitError
+	if err != nil && !(errors.As(err, &exitErr) && exitErr.ExitCode() == 1) {
+		return fmt.Errorf("failed to stage generated files: %w", err)
+	}
+	ignored := strings.Fields(out)
+	paths = slices.DeleteFunc(slices.Clone(paths), func(p string) bool { return slices.Contains(ignored, p) })
+	if len(paths) == 0 {
+		return nil
+	}
+	if _, err := git(string(g.Dir), append([]string{"add", "--"}, paths...)...); err != nil {
+		return fmt.Errorf("failed to stage generated files: %w", err)
+	}
+	return nil
+}
+
+// Dirty returns the paths that git status reports as modified, staged or
+// untracked anywhere in the working tree, relative to its top. Unstaged and
+// untracked changes below the exclude paths, relative to the sink, are left
+// out; staged ones are not, since a commit would include them.
+func (g *Git) Dirty(exclude ...string) ([]string, error) {
+	args := []string{"status", "--porcelain", "--", ":/"}
+	for _, path := range exclude {
+		args = append(args, ":(exclude)"+path)
+	}
+	out, err := git(string(g.Dir), args...)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, line := range strings.Split(out, "\n") {
+		if len(line) > 3 {
+			paths = append(paths, line[3:])
+		}
+	}
+	if len(exclude) == 0 {
+		return paths, nil
+	}
+	staged, err := git(string(g.Dir), append([]string{"diff", "--cached", "--name-only", "--"}, exclude...)...)
+	if err != nil {
+		return nil, err
+	}
+	return append(paths, strings.Fields(staged)...), nil
+}
+
+// CheckBranch reports an error unless name is a valid name for a new
+// branch.
+func (g *Git) CheckBranch(name string) error {
+	if _, err := git(string(g.Dir), "check-ref-format", "--branch", name); err != nil {
+		return fmt.Errorf("invalid branch name %q", name)
+	}
+	// rev-parse --verify fails when the branch does not exist.
+	if _, err := git(string(g.Dir), "rev-parse", "--verify", "--quiet", "refs/heads/"+name); err == nil {
+		return fmt.Errorf("branch %s already exists", name)
+	}
+	return nil
+}
+
+// CreateBranch creates the branch name at HEAD and switches to it, carrying
+// over the changes in the working tree.
+func (g *Git) CreateBranch(name string) error {
+	if err := g.CheckBranch(name); err != nil {
+		return err
+	}
+	if _, err := git(string(g.Dir), "checkout", "-q", "-b", name); err != nil {
+		return fmt.Errorf("failed to create branch %s: %w", name, err)
+	}
+	return nil
+}
+
+// Commit records the staged changes with message and returns the new
+// commit's hash. It returns "" without committing if nothing is staged.
+func (g *Git) Commit(message string) (string, error) {
+	// diff --quiet exits with 1 when something is staged.
+	_, err := git(string(g.Dir), "diff", "--cached", "--quiet")
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return "", nil
+	case !errors.As(err, &exitErr) || exitErr.ExitCode() != 1:
+		return "", err
+	}
+	if _, err := git(string(g.Dir), "commit", "-q", "-m", message); err != nil {
+		return "", fmt.Errorf("failed to commit: %w", err)
+	}
+	hash, err := git(string(g.Dir), "rev-parse", "HEAD")
+	return strings.TrimSpace(hash), err
+}
+
+// git runs git in dir and returns its standard output. Errors include
+// git's standard error and wrap the *exec.ExitError.
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return stdout.String(), fmt.Errorf("git %s: %s: %w", args[0], msg, err)
+		}
+		return stdout.String(), fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,139 @@
+package workspace
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+)
+
+// initRepo creates a git repository with an identity to commit as.
+func initRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.name", "Test"},
+		{"config", "user.email", "test@example.com"},
+	} {
+		if _, err := git(dir, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestGit(t *testing.T) {
+	dir := initRepo(t)
+	if err := os.WriteFile(filepath.Join(dir, "unrelated.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := NewGit(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Write(w, []scaffold.File{{Path: "ci/build.yaml", Content: "on: push\n", Mode: 0644}}, Options{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	staged, err := git(dir, "diff", "--cached", "--name-only")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(staged) != "ci/build.yaml" {
+		t.Errorf("staged %q, want only the generated file", staged)
+	}
+}
+
+func TestNewGit_NotARepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	if _, err := NewGit(t.TempDir()); err == nil {
+		t.Error("expected an error outside a git working tree")
+	}
+}
+
+func TestGit_BranchAndCommit(t *testing.T) {
+	dir := initRepo(t)
+	if err := os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("ignored.txt\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := git(dir, "add", ".gitignore"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := git(dir, "commit", "-q", "-m", "initial"); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := NewGit(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dirty, err := w.Dirty(); err != nil || len(dirty) != 0 {
+		t.Fatalf("Dirty() = %v, %v on a clean tree", dirty, err)
+	}
+	if err := w.CreateBranch("bad..name"); err == nil {
+		t.Error("expected an error for an invalid branch name")
+	}
+	current, _ := git(dir, "branch", "--show-current")
+	if err := w.CreateBranch(strings.TrimSpace(current)); err == nil {
+		t.Error("expected an error for an existing branch")
+	}
+
+	files := []scaffold.File{
+		{Path: "Dockerfile", Content: "FROM scratch\n", Mode: 0644},
+		{Path: "ignored.txt", Content: "x\n", Mode: 0644},
+	}
+	if _, err := Write(w, files, Options{}); err != nil {
+		t.Fatal(err)
+	}
+	if dirty, _ := w.Dirty(); len(dirty) != 1 || dirty[0] != "Dockerfile" {
+		t.Errorf("Dirty() = %v after writing", dirty)
+	}
+	if dirty, _ := w.Dirty("Dockerfile"); len(dirty) != 0 {
+		t.Errorf("Dirty(Dockerfile) = %v after writing", dirty)
+	}
+	// Staged changes count even below the exclude paths.
+	if _, err := git(dir, "add", "Dockerfile"); err != nil {
+		t.Fatal(err)
+	}
+	if dirty, _ := w.Dirty("Dockerfile"); len(dirty) != 1 || dirty[0] != "Dockerfile" {
+		t.Errorf("Dirty(Dockerfile) = %v with Dockerfile staged", dirty)
+	}
+	if _, err := git(dir, "reset", "-q", "--", "Dockerfile"); err != nil {
+		t.Fatal(err)
+	}
+	// The changes follow to the new branch.
+	if err := w.CreateBranch("scaffold"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	hash, err := w.Commit("feat: add Dockerfile")
+	if err != nil || hash == "" {
+		t.Fatalf("Commit() = %q, %v", hash, err)
+	}
+
+	branch, _ := git(dir, "branch", "--show-current")
+	committed, _ := git(dir, "show", "--name-only", "--format=%s", "HEAD")
+	if strings.TrimSpace(branch) != "scaffold" || strings.TrimSpace(committed) != "feat: add Dockerfile\n\nDockerfile" {
+		t.Errorf("branch %q, commit %q", branch, committed)
+	}
+
+	// Nothing left to commit.
+	if hash, err := w.Commit("empty"); err != nil || hash != "" {
+		t.Errorf("Commit() = %q, %v with nothing staged", hash, err)
+	}
+}
@@ -0,0 +1,276 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/modelcontextprotocol/platform.mcp/internal/archive"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+)
+
+// Reader reads the current content of generated files from an output sink.
+type Reader interface {
+	// ReadFile returns the content of the file at path, a slash-separated
+	// path relative to the sink. If there is none, the error wraps
+	// fs.ErrNotExist.
+	ReadFile(path string) ([]byte, error)
+}
+
+// Writer is an output sink for generated files: a directory on disk, memory,
+// an archive or a git working tree. Write applies the conflict policy on top
+// of it, so every sink behaves the same way. Sinks that buffer their output,
+// such as Archive and Git, also implement io.Closer; call Close once every
+// file is written.
+type Writer interface {
+	Reader
+	// WriteFile stores content at path, replacing any previous content.
+	WriteFile(path string, content []byte, mode fs.FileMode) error
+}
+
+// Stager is implemented by sinks whose writes can fail partway, such as
+// Dir. Write stages every file before putting any in place, so that a
+// failure can be rolled back.
+type Stager interface {
+	Writer
+	// Stage prepares content to be written at path without changing what
+	// is in place.
+	Stage(path string, content []byte, mode fs.FileMode) (Staged, error)
+}
+
+// Staged is a file prepared by a Stager.
+type Staged interface {
+	// Commit puts the file in place, keeping what it replaces until Close.
+	Commit() error
+	// Rollback restores what was in place before Commit, or discards the
+	// staged file if it was not committed.
+	Rollback() error
+	// Close discards what Commit kept for Rollback.
+	Close() error
+}
+
+// Dir is a Writer for the directory tree rooted at the named directory.
+// Files are staged as temporary files next to their target and renamed into
+// place, so each one is replaced atomically.
+type Dir string
+
+// resolve returns the file path of the generated file at path, with
+// symlinks in its parent directories resolved. It refuses a path whose
+// parent is a symlink leading outside d.
+func (d Dir) resolve(path string) (string, error) {
+	target, err := Join(string(d), path)
+	if err != nil {
+		return "", err
+	}
+	root, err := ResolvePath(string(d))
+	if err != nil {
+		return "", err
+	}
+	parent, err := ResolvePath(filepath.Dir(target))
+	if err != nil {
+		return "", err
+	}
+	if !Within(root, parent) {
+		return "", fmt.Errorf("file path %q escapes the target directory through a symlink", path)
+	}
+	return filepath.Join(parent, filepath.Base(target)), nil
+}
+
+// ReadFile implements Reader.
+func (d Dir) ReadFile(path string) ([]byte, error) {
+	target, err := d.resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(target)
+}
+
+// WriteFile implements Writer, creating parent directories as needed.
+func (d Dir) WriteFile(path string, content []byte, mode fs.FileMode) error {
+	target, err := d.resolve(path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(target, content, mode); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// Stage implements Stager.
+func (d Dir) Stage(path string, content []byte, mode fs.FileMode) (Staged, error) {
+	target, err := d.resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	s := &dirStaged{target: target}
+	for dir := filepath.Dir(target); ; dir = filepath.Dir(dir) {
+		if _, err := os.Lstat(dir); !errors.Is(err, fs.ErrNotExist) || dir == filepath.Dir(dir) {
+			break
+		}
+		s.dirs = append(s.dirs, dir)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		_ = s.Rollback()
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), "."+filepath.Base(target)+".*.tmp")
+	if err != nil {
+		_ = s.Rollback()
+		return nil, fmt.Errorf("failed to stage %s: %w", path, err)
+	}
+	s.temp = tmp.Name()
+	_, err = tmp.Write(content)
+	if err == nil {
+		err = tmp.Chmod(mode)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = s.Rollback()
+		return nil, fmt.Errorf("failed to stage %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// dirStaged is a file staged by Dir. The file it replaces is kept as a hard
+// link, or moved aside where links are not supported.
+type dirStaged struct {
+	target, temp, backup string
+	dirs                 []string // created by Stage, innermost first
+	committed            bool
+}
+
+func (s *dirStaged) Commit() error {
+	if info, err := os.Lstat(s.target); err == nil {
+		if info.IsDir() {
+			return fmt.Errorf("failed to write file: %s is a directory", s.target)
+		}
+		s.backup = s.temp + ".orig"
+		if err := os.Link(s.target, s.backup); err != nil {
+			if err := os.Rename(s.target, s.backup); err != nil {
+				s.backup = ""
+				return fmt.Errorf("failed to write file: %w", err)
+			}
+		}
+	}
+	if err := os.Rename(s.temp, s.target); err != nil {
+		if s.backup != "" && os.Rename(s.backup, s.target) == nil {
+			s.backup = ""
+		}
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	s.committed = true
+	return nil
+}
+
+func (s *dirStaged) Rollback() error {
+	var err error
+	switch {
+	case s.committed && s.backup != "":
+		err = os.Rename(s.backup, s.target)
+		s.backup = ""
+	case s.committed:
+		err = os.Remove(s.target)
+	case s.temp != "":
+		if err = os.Remove(s.temp); errors.Is(err, fs.ErrNotExist) {
+			err = nil
+		}
+	}
+	s.committed = false
+	// Directories that other files were written to are not empty.
+	for _, dir := range s.dirs {
+		_ = os.Remove(dir)
+	}
+	return err
+}
+
+func (s *dirStaged) Close() error {
+	if s.backup != "" {
+		return os.Remove(s.backup)
+	}
+	return nil
+}
+
+// Memory is a Writer that keeps files in a map, by path.
+type Memory map[string]scaffold.File
+
+// ReadFile implements Reader.
+func (m Memory) ReadFile(path string) ([]byte, error) {
+	f, ok := m[path]
+	if !ok {
+		return nil, &fs.PathError{Op: "read", Path: path, Err: fs.ErrNotExist}
+	}
+	return []byte(f.Content), nil
+}
+
+// WriteFile implements Writer.
+func (m Memory) WriteFile(path string, content []byte, mode fs.FileMode) error {
+	m[path] = scaffold.File{Path: path, Content: string(content), Mode: uint32(mode)}
+	return nil
+}
+
+// DryRun is a Writer that reads from Base but writes nothing, so Write
+// reports what it would do. A nil Base has no files.
+type DryRun struct {
+	Base Reader
+}
+
+// ReadFile implements Reader.
+func (d DryRun) ReadFile(path string) ([]byte, error) {
+	if d.Base == nil {
+		return nil, &fs.PathError{Op: "read", Path: path, Err: fs.ErrNotExist}
+	}
+	return d.Base.ReadFile(path)
+}
+
+// WriteFile implements Writer and discards content.
+func (DryRun) WriteFile(string, []byte, fs.FileMode) error {
+	return nil
+}
+
+// Archive is a Writer that packs files into an archive, in one of the
+// archive package formats. The archive is written to the underlying writer
+// on Close.
+type Archive struct {
+	w      io.Writer
+	format string
+	files  Memory
+	order  []string
+}
+
+// NewArchive returns an Archive writing to w in format.
+func NewArchive(w io.Writer, format string) *Archive {
+	return &Archive{w: w, format: format, files: Memory{}}
+}
+
+// ReadFile implements Reader. Only files written to the archive exist.
+func (a *Archive) ReadFile(path string) ([]byte, error) {
+	return a.files.ReadFile(path)
+}
+
+// WriteFile implements Writer.
+func (a *Archive) WriteFile(path string, content []byte, mode fs.FileMode) error {
+	if _, ok := a.files[path]; !ok {
+		a.order = append(a.order, path)
+	}
+	return a.files.WriteFile(path, content, mode)
+}
+
+// Close writes the archive, with the files in the order they were first
+// written.
+func (a *Archive) Close() error {
+	files := make([]scaffold.File, 0, len(a.order))
+	for _, path := range a.order {
+		files = append(files, a.files[path])
+	}
+	return archive.Write(a.w, a.format, files)
+}
@@ -0,0 +1,59 @@
+package workspace
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/platform.mcp/internal/archive"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+)
+
+func TestDryRun(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "existing.txt"), []byte("original"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	files := []scaffold.File{
+		{Path: "new.txt", Content: "new", Mode: 0644},
+		{Path: "existing.txt", Content: "generated", Mode: 0644},
+	}
+
+	results, err := Write(DryRun{Base: Dir(dir)}, files, Options{Policy: PolicyOverwrite})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Action != ActionCreated || results[1].Action != ActionOverwritten {
+		t.Errorf("results = %+v", results)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "new.txt")); !os.IsNotExist(err) {
+		t.Errorf("dry run created a file: %v", err)
+	}
+	if content, _ := os.ReadFile(filepath.Join(dir, "existing.txt")); string(content) != "original" {
+		t.Errorf("dry run changed a file: %q", content)
+	}
+}
+
+func TestArchive(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewArchive(&buf, archive.FormatTar)
+	if _, err := Write(w, []scaffold.File{{Path: "run.sh", Content: "#!/bin/sh\n", Mode: 0755}}, Options{}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Error("the archive was written before Close")
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	hdr, err := tar.NewReader(&buf).Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hdr.Name != "run.sh" || hdr.Mode != 0755 {
+		t.Errorf("entry = %s (%o)", hdr.Name, hdr.Mode)
+	}
+}
@@ -0,0 +1,281 @@
+// Package workspace writes generated scaffold files into an output sink: a
+// directory on disk, memory, an archive or a git working tree. It holds the
+// overwrite and conflict rules shared by the CLI and the MCP server so both
+// behave the same way.
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/modelcontextprotocol/platform.mcp/internal/diff"
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+)
+
+// Action describes what happened to a single file.
+type Action string
+
+const (
+	ActionCreated     Action = "created"
+	ActionSkipped     Action = "skipped"
+	ActionOverwritten Action = "overwritten"
+	ActionUnchanged   Action = "unchanged"
+	ActionMerged      Action = "merged"
+	ActionFailed      Action = "failed"
+	ActionRolledBack  Action = "rolled-back"
+)
+
+// Result reports the outcome for one generated file. Conflicts counts the
+// regions of a merged file marked as conflicts; Error is set for the file
+// that made Write fail.
+type Result struct {
+	Path      string `json:"path"`
+	Action    Action `json:"action"`
+	Conflicts int    `json:"conflicts,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Policy decides what Write does with a generated file whose path already
+// holds different content.
+type Policy string
+
+const (
+	// PolicySkip keeps the existing file.
+	PolicySkip Policy = "skip"
+	// PolicyOverwrite replaces it.
+	PolicyOverwrite Policy = "overwrite"
+	// PolicyPrompt asks Options.Prompt.
+	PolicyPrompt Policy = "prompt"
+	// PolicyFail aborts the write with ErrConflict.
+	PolicyFail Policy = "fail"
+	// PolicyMerge three-way merges the generated content into the existing
+	// file against Options.Base, marking regions changed on both sides as
+	// conflicts.
+	PolicyMerge Policy = "merge"
+)
+
+// Policies lists the conflict policies.
+var Policies = []Policy{PolicySkip, PolicyOverwrite, PolicyPrompt, PolicyFail, PolicyMerge}
+
+// ErrConflict is returned by Write under PolicyFail.
+var ErrConflict = errors.New("file already exists")
+
+// ConflictFunc decides whether an existing file at path may be overwritten.
+// Returning an error aborts the write.
+type ConflictFunc func(path string) (overwrite bool, err error)
+
+// Options configures Write.
+type Options struct {
+	// Policy applies to existing files with different content. The zero
+	// value is PolicySkip.
+	Policy Policy
+	// Prompt is consulted under PolicyPrompt. A nil Prompt skips the file.
+	Prompt ConflictFunc
+	// Base holds the files as last generated, for PolicyMerge. Without it,
+	// or without a file in it, the merge base is empty.
+	Base Reader
+}
+
+// Write writes files to w and reports what was done with each one. Files
+// whose content is already in place are left alone; other existing files
+// are handled according to opts.Policy.
+//
+// Nothing is written until the action and content of every file are
+// decided. Sinks that implement Stager then have every file staged before
+// any is put in place, and if one fails, the files already put in place are
+// rolled back to their previous content. Other sinks are written file by
+// file. On error, the results still cover every file: the one that failed
+// is reported as ActionFailed, and those that were to be written but are
+// not in place as ActionRolledBack. Write does not close w.
+func Write(w Writer, files []scaffold.File, opts Options) ([]Result, error) {
+	results := make([]Result, len(files))
+	contents := make([]string, len(files))
+	for i, file := range files {
+		var err error
+		results[i], contents[i], err = plan(w, file, opts)
+		if err != nil {
+			// Nothing was written.
+			for j := range results {
+				if j > i || writes(results[j].Action) {
+					results[j] = Result{Path: files[j].Path, Action: ActionRolledBack}
+				}
+			}
+			return failed(results, i, err), err
+		}
+	}
+
+	var pending []int
+	for i, r := range results {
+		if writes(r.Action) {
+			pending = append(pending, i)
+		}
+	}
+
+	stager, ok := w.(Stager)
+	if !ok {
+		for n, i := range pending {
+			if err := w.WriteFile(files[i].Path, []byte(contents[i]), fs.FileMode(files[i].Mode)); err != nil {
+				for _, j := range pending[n+1:] {
+					results[j].Action = ActionRolledBack
+				}
+				return failed(results, i, err), err
+			}
+		}
+		return results, nil
+	}
+
+	staged := make([]Staged, 0, len(pending))
+	rollback := func(i int, err error) ([]Result, error) {
+		errs := []error{err}
+		for n := len(staged) - 1; n >= 0; n-- {
+			if err := staged[n].Rollback(); err != nil {
+				errs = append(errs, fmt.Errorf("failed to roll back %s: %w", results[pending[n]].Path, err))
+			}
+		}
+		for _, i := range pending {
+			results[i].Action = ActionRolledBack
+		}
+		return failed(results, i, err), errors.Join(errs...)
+	}
+	for _, i := range pending {
+		s, err := stager.Stage(files[i].Path, []byte(contents[i]), fs.FileMode(files[i].Mode))
+		if err != nil {
+			return rollback(i, err)
+		}
+		staged = append(staged, s)
+	}
+	for n, s := range staged {
+		if err := s.Commit(); err != nil {
+			return rollback(pending[n], err)
+		}
+	}
+	// The files are in place; a backup left behind does not undo that.
+	for _, s := range staged {
+		_ = s.Close()
+	}
+	return results, nil
+}
+
+// plan decides what Write does with file, and returns the content to write.
+func plan(w Writer, file scaffold.File, opts Options) (Result, string, error) {
+	result := Result{Path: file.Path, Action: ActionCreated}
+	if _, err := Join("", file.Path); err != nil {
+		return result, "", err
+	}
+	current, err := w.ReadFile(file.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return result, file.Content, nil
+	}
+	if err != nil {
+		return result, "", err
+	}
+	var content string
+	result.Action, content, result.Conflicts, err = resolveConflict(file, string(current), opts)
+	return result, content, err
+}
+
+// writes reports whether Write writes a file it decided to take action on.
+func writes(action Action) bool {
+	return action != ActionSkipped && action != ActionUnchanged
+}
+
+// failed reports results[i] as the file that made Write fail with err.
+func failed(results []Result, i int, err error) []Result {
+	results[i].Action = ActionFailed
+	results[i].Error = err.Error()
+	return results
+}
+
+// resolveConflict applies opts.Policy to a file whose path holds current,
+// and returns the action to take and the content to write.
+func resolveConflict(file scaffold.File, current string, opts Options) (Action, string, int, error) {
+	if current == file.Content {
+		return ActionUnchanged, current, 0, nil
+	}
+	switch opts.Policy {
+	case PolicySkip, "":
+		return ActionSkipped, current, 0, nil
+	case PolicyOverwrite:
+		return ActionOverwritten, file.Content, 0, nil
+	case PolicyPrompt:
+		if opts.Prompt == nil {
+			return ActionSkipped, current, 0, nil
+		}
+		overwrite, err := opts.Prompt(file.Path)
+		if err != nil {
+			return "", "", 0, err
+		}
+		if !overwrite {
+			return ActionSkipped, current, 0, nil
+		}
+		return ActionOverwritten, file.Content, 0, nil
+	case PolicyFail:
+		return "", "", 0, fmt.Errorf("%s: %w", file.Path, ErrConflict)
+	case PolicyMerge:
+		var base []byte
+		if opts.Base != nil {
+			var err error
+			if base, err = opts.Base.ReadFile(file.Path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return "", "", 0, err
+			}
+		}
+		merged, conflicts := diff.Merge(string(base), current, file.Content, "local", "template")
+		if merged == current {
+			return ActionUnchanged, current, 0, nil
+		}
+		return ActionMerged, merged, conflicts, nil
+	default:
+		return "", "", 0, fmt.Errorf("unknown conflict policy %q", opts.Policy)
+	}
+}
+
+// Join joins a generated file path onto dir, refusing paths that would escape it.
+func Join(dir, rel string) (string, error) {
+	if filepath.IsAbs(rel) || !filepath.IsLocal(filepath.FromSlash(rel)) {
+		return "", fmt.Errorf("file path %q escapes the target directory", rel)
+	}
+	return filepath.Join(dir, filepath.FromSlash(rel)), nil
+}
+
+// Within reports whether path is root itself or lies below it. Both paths
+// must be absolute and cleaned.
+func Within(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
+
+// ResolvePath makes path absolute and resolves symlinks in its longest
+// existing prefix, so a not-yet-created directory can still be checked
+// against a root.
+func ResolvePath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	var missing []string
+	current := abs
+	for {
+		resolved, err := filepath.EvalSymlinks(current)
+		if err == nil {
+			parts := append([]string{resolved}, missing...)
+			return filepath.Join(parts...), nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return abs, nil
+		}
+		missing = append([]string{filepath.Base(current)}, missing...)
+		current = parent
+	}
+}
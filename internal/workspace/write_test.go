@@ -0,0 +1,294 @@
+package workspace
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/platform.mcp/pkg/scaffold"
+)
+
+func TestWrite(t *testing.T) {
+	files := []scaffold.File{
+		{Path: "new.txt", Content: "new", Mode: 0644},
+		{Path: "nested/existing.txt", Content: "generated", Mode: 0644},
+	}
+
+	tests := []struct {
+		name        string
+		opts        Options
+		wantActions []Action
+		wantContent string
+		wantErr     bool
+	}{
+		{"Skip By Default", Options{}, []Action{ActionCreated, ActionSkipped}, "original", false},
+		{"Overwrite", Options{Policy: PolicyOverwrite}, []Action{ActionCreated, ActionOverwritten}, "generated", false},
+		{
+			"Confirm Overwrite",
+			Options{Policy: PolicyPrompt, Prompt: func(string) (bool, error) { return true, nil }},
+			[]Action{ActionCreated, ActionOverwritten}, "generated", false,
+		},
+		{
+			"Prompt Error",
+			Options{Policy: PolicyPrompt, Prompt: func(string) (bool, error) { return false, errors.New("refused") }},
+			[]Action{ActionRolledBack, ActionFailed}, "original", true,
+		},
+		{"Fail", Options{Policy: PolicyFail}, []Action{ActionRolledBack, ActionFailed}, "original", true},
+		{
+			"Merge",
+			Options{Policy: PolicyMerge, Base: Memory{"nested/existing.txt": {Content: "original"}}},
+			[]Action{ActionCreated, ActionMerged}, "generated", false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			existing := filepath.Join(dir, "nested", "existing.txt")
+			if err := os.MkdirAll(filepath.Dir(existing), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(existing, []byte("original"), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			results, err := Write(Dir(dir), files, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Write() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(results) != len(tt.wantActions) {
+				t.Fatalf("got %d results, want %d", len(results), len(tt.wantActions))
+			}
+			for i, want := range tt.wantActions {
+				if results[i].Action != want {
+					t.Errorf("result %d action = %s, want %s", i, results[i].Action, want)
+				}
+			}
+
+			content, _ := os.ReadFile(existing)
+			if string(content) != tt.wantContent {
+				t.Errorf("existing file content = %q, want %q", content, tt.wantContent)
+			}
+			// A failed write leaves nothing behind.
+			if _, err := os.Stat(filepath.Join(dir, "new.txt")); tt.wantErr && err == nil {
+				t.Error("new.txt was written although Write failed")
+			}
+		})
+	}
+}
+
+func TestWrite_RollsBackDir(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "existing.txt")
+	if err := os.WriteFile(existing, []byte("original"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Staging conflict/file.txt creates the directory conflict, so the file
+	// conflict cannot be put in place after the first two are.
+	files := []scaffold.File{
+		{Path: "existing.txt", Content: "generated", Mode: 0644},
+		{Path: "nested/deep/new.txt", Content: "new", Mode: 0644},
+		{Path: "conflict", Content: "x", Mode: 0644},
+		{Path: "conflict/file.txt", Content: "x", Mode: 0644},
+	}
+	results, err := Write(Dir(dir), files, Options{Policy: PolicyOverwrite})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	want := []Action{ActionRolledBack, ActionRolledBack, ActionFailed, ActionRolledBack}
+	for i, r := range results {
+		if r.Action != want[i] {
+			t.Errorf("%s: action = %s, want %s", r.Path, r.Action, want[i])
+		}
+	}
+	if results[2].Error == "" {
+		t.Error("the failed file has no error")
+	}
+
+	// Rollback removes the new files, the directories created for them and
+	// the staged files, and restores the replaced ones.
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	if strings.Join(names, ",") != "existing.txt" {
+		t.Errorf("directory holds %v after rollback", names)
+	}
+	if content, _ := os.ReadFile(existing); string(content) != "original" {
+		t.Errorf("existing file content = %q after rollback", content)
+	}
+}
+
+// failingStager is a Memory whose commits fail for one path.
+type failingStager struct {
+	Memory
+	fail string
+}
+
+func (f failingStager) Stage(path string, content []byte, mode fs.FileMode) (Staged, error) {
+	return &memoryStaged{f, path, content, mode, nil, false}, nil
+}
+
+type memoryStaged struct {
+	f         failingStager
+	path      string
+	content   []byte
+	mode      fs.FileMode
+	previous  *scaffold.File
+	committed bool
+}
+
+func (s *memoryStaged) Commit() error {
+	if s.path == s.f.fail {
+		return errors.New("disk full")
+	}
+	if prev, ok := s.f.Memory[s.path]; ok {
+		s.previous = &prev
+	}
+	s.committed = true
+	return s.f.WriteFile(s.path, s.content, s.mode)
+}
+
+func (s *memoryStaged) Rollback() error {
+	switch {
+	case s.committed && s.previous != nil:
+		s.f.Memory[s.path] = *s.previous
+	case s.committed:
+		delete(s.f.Memory, s.path)
+	}
+	return nil
+}
+
+func (s *memoryStaged) Close() error { return nil }
+
+func TestWrite_RollsBackCommitted(t *testing.T) {
+	w := failingStager{Memory: Memory{"a.txt": {Path: "a.txt", Content: "original"}}, fail: "c.txt"}
+	files := []scaffold.File{
+		{Path: "a.txt", Content: "generated"},
+		{Path: "b.txt", Content: "new"},
+		{Path: "c.txt", Content: "new"},
+		{Path: "d.txt", Content: "new"},
+	}
+	results, err := Write(w, files, Options{Policy: PolicyOverwrite})
+	if err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Fatalf("err = %v", err)
+	}
+	want := []Action{ActionRolledBack, ActionRolledBack, ActionFailed, ActionRolledBack}
+	for i, r := range results {
+		if r.Action != want[i] {
+			t.Errorf("%s: action = %s, want %s", r.Path, r.Action, want[i])
+		}
+	}
+	if len(w.Memory) != 1 || w.Memory["a.txt"].Content != "original" {
+		t.Errorf("memory after rollback = %v", w.Memory)
+	}
+}
+
+func TestWrite_RejectsEscapingPaths(t *testing.T) {
+	dir := t.TempDir()
+	for _, p := range []string{"../outside.txt", "/etc/passwd", "a/../../outside.txt"} {
+		if _, err := Write(Dir(dir), []scaffold.File{{Path: p, Content: "x", Mode: 0644}}, Options{}); err == nil {
+			t.Errorf("expected error for path %q", p)
+		}
+	}
+}
+
+func TestWrite_RejectsSymlinkedParent(t *testing.T) {
+	dir, outside := t.TempDir(), t.TempDir()
+	if err := os.Symlink(outside, filepath.Join(dir, ".github")); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+
+	files := []scaffold.File{{Path: ".github/workflows/ci.yaml", Content: "x", Mode: 0644}}
+	if _, err := Write(Dir(dir), files, Options{Policy: PolicyOverwrite}); err == nil {
+		t.Error("expected an error for a path through a symlink leading outside the directory")
+	}
+	entries, err := os.ReadDir(outside)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("wrote outside the directory: %v", entries)
+	}
+}
+
+func TestWrite_Unchanged(t *testing.T) {
+	w := Memory{"a.txt": {Path: "a.txt", Content: "same"}}
+	results, err := Write(w, []scaffold.File{{Path: "a.txt", Content: "same"}}, Options{Policy: PolicyFail})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Action != ActionUnchanged {
+		t.Errorf("action = %s, want %s", results[0].Action, ActionUnchanged)
+	}
+}
+
+func TestWrite_MergeConflict(t *testing.T) {
+	w := Memory{"a.txt": {Path: "a.txt", Content: "local\n"}}
+	base := Memory{"a.txt": {Path: "a.txt", Content: "base\n"}}
+	results, err := Write(w, []scaffold.File{{Path: "a.txt", Content: "template\n"}}, Options{Policy: PolicyMerge, Base: base})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Action != ActionMerged || results[0].Conflicts != 1 {
+		t.Errorf("result = %+v, want merged with 1 conflict", results[0])
+	}
+	if !strings.Contains(w["a.txt"].Content, "<<<<<<< local") {
+		t.Errorf("merged content lacks conflict markers:\n%s", w["a.txt"].Content)
+	}
+}
+
+func TestWithin(t *testing.T) {
+	tests := []struct {
+		root, path string
+		want       bool
+	}{
+		{"/work", "/work", true},
+		{"/work", "/work/repo", true},
+		{"/work", "/work-other", false},
+		{"/work", "/", false},
+		{"/work", "/work/../etc", false},
+	}
+	for _, tt := range tests {
+		if got := Within(tt.root, filepath.Clean(tt.path)); got != tt.want {
+			t.Errorf("Within(%q, %q) = %v, want %v", tt.root, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestResolvePath_Symlink(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	outside := filepath.Join(dir, "outside")
+	root := filepath.Join(dir, "root")
+	for _, d := range []string{outside, root} {
+		if err := os.Mkdir(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(outside, filepath.Join(root, "link")); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+
+	resolved, err := ResolvePath(filepath.Join(root, "link", "new-dir"))
+	if err != nil {
+		t.Fatalf("ResolvePath failed: %v", err)
+	}
+	if want := filepath.Join(outside, "new-dir"); resolved != want {
+		t.Errorf("ResolvePath = %q, want %q", resolved, want)
+	}
+	if Within(root, resolved) {
+		t.Error("symlinked path should not be considered inside root")
+	}
+}
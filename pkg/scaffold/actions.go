@@ -1,6 +1,7 @@
 package scaffold
 
 import (
+	"context"
 	"embed"
 )
 
@@ -14,12 +15,12 @@ type ActionsGenerator struct {
 // Ensure ActionsGenerator implements Generator
 var _ Generator = (*ActionsGenerator)(nil)
 
-func (g *ActionsGenerator) Generate(cfg Config) ([]File, error) {
+func (g *ActionsGenerator) Generate(ctx context.Context, cfg Config) ([]File, error) {
 	// Use the manifest-driven generator but ensure only Actions are generated
 	limitedCfg := cfg
 	limitedCfg.WithActions = true
 	limitedCfg.WithDocker = false
 	limitedCfg.WithFlux = false
 
-	return Generate(limitedCfg)
+	return GenerateContext(ctx, limitedCfg)
 }
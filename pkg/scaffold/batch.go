@@ -0,0 +1,122 @@
+package scaffold
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/modelcontextprotocol/platform.mcp/internal/templates"
+)
+
+// Component is one entry of a batch: a project configuration rendered
+// below Path, a slash-separated directory relative to the repository root.
+// An empty Path renders at the root.
+type Component struct {
+	Path string `json:"path,omitempty" jsonschema:"Directory of the component relative to the repository root, e.g. services/api. Empty means the root."`
+	Config
+}
+
+// Collision is a file path produced by more than one component.
+type Collision struct {
+	Path       string
+	Components []string
+}
+
+// CollisionError reports every path collision found in a batch.
+type CollisionError struct {
+	Collisions []Collision
+}
+
+func (e *CollisionError) Error() string {
+	parts := make([]string, len(e.Collisions))
+	for i, c := range e.Collisions {
+		parts[i] = fmt.Sprintf("%s (from %s)", c.Path, strings.Join(c.Components, ", "))
+	}
+	return "components write the same files: " + strings.Join(parts, "; ")
+}
+
+// GenerateBatch renders every component below its path and returns the
+// merged file set, in component order. It fails with a *CollisionError if
+// two components would write the same file.
+func GenerateBatch(ctx context.Context, components []Component) ([]File, error) {
+	if len(components) == 0 {
+		return nil, fmt.Errorf("batch has no components")
+	}
+
+	manifest, err := templates.GetManifest()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template manifest: %w", err)
+	}
+	total := 0
+	for _, component := range components {
+		total += len(FilterTemplates(manifest, component.Config))
+	}
+
+	// Report progress across the whole batch rather than per component.
+	progress := progressFrom(ctx)
+	completed, currentDir := 0, "."
+	batchCtx := WithProgress(ctx, func(p Progress) {
+		progress(Progress{
+			Template:  p.Template,
+			Target:    path.Join(currentDir, p.Target),
+			Completed: completed + p.Completed,
+			Total:     total,
+		})
+	})
+
+	var files []File
+	owners := make(map[string][]string)
+	for i, component := range components {
+		dir, err := componentDir(component.Path)
+		if err != nil {
+			return nil, fmt.Errorf("component %d (%s): %w", i, component.ProjectName, err)
+		}
+		currentDir = dir
+
+		generated, err := GenerateContext(batchCtx, component.Config)
+		if err != nil {
+			return nil, fmt.Errorf("component %d (%s): %w", i, component.ProjectName, err)
+		}
+
+		label := component.ProjectName
+		if dir != "." {
+			label += " in " + dir
+		}
+		completed += len(generated)
+		for _, f := range generated {
+			f.Path = path.Join(dir, f.Path)
+			owners[f.Path] = append(owners[f.Path], label)
+			files = append(files, f)
+		}
+	}
+
+	var collisions []Collision
+	seen := make(map[string]bool)
+	for _, f := range files {
+		if len(owners[f.Path]) > 1 && !seen[f.Path] {
+			seen[f.Path] = true
+			collisions = append(collisions, Collision{Path: f.Path, Components: owners[f.Path]})
+		}
+	}
+	if len(collisions) > 0 {
+		return nil, &CollisionError{Collisions: collisions}
+	}
+	return files, nil
+}
+
+// componentDir cleans a component path and rejects paths that leave the
+// repository root.
+func componentDir(p string) (string, error) {
+	if p == "" {
+		return ".", nil
+	}
+	if path.IsAbs(p) || strings.Contains(p, `\`) {
+		return "", fmt.Errorf("path %q must be a relative, slash-separated directory", p)
+	}
+	cleaned := path.Clean(p)
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("path %q escapes the repository root", p)
+	}
+	return cleaned, nil
+}
@@ -0,0 +1,97 @@
+package scaffold
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGenerateBatch_Monorepo(t *testing.T) {
+	files, err := GenerateBatch(context.Background(), []Component{
+		{Path: "services/api", Config: Config{ProjectName: "api", WithDocker: true}},
+		{Path: "services/web/", Config: Config{ProjectName: "web", WithDocker: true}},
+		{Config: Config{ProjectName: "mono", WithActions: true, WorkflowType: "go"}},
+	})
+	if err != nil {
+		t.Fatalf("GenerateBatch failed: %v", err)
+	}
+
+	paths := make(map[string]string)
+	for _, f := range files {
+		paths[f.Path] = f.Content
+	}
+	for _, want := range []string{"services/api/Dockerfile", "services/web/Dockerfile", ".github/workflows/ci.yaml", ".github/workflows/go.yaml"} {
+		if _, ok := paths[want]; !ok {
+			t.Errorf("missing %s in %v", want, paths)
+		}
+	}
+	if !strings.Contains(paths["services/api/docker-build.yaml"], "api") {
+		t.Error("api component was not rendered with its own config")
+	}
+}
+
+func TestGenerateBatch_Collisions(t *testing.T) {
+	_, err := GenerateBatch(context.Background(), []Component{
+		{Config: Config{ProjectName: "api", WithDocker: true}},
+		{Path: ".", Config: Config{ProjectName: "web", WithDocker: true}},
+		{Path: "services/worker", Config: Config{ProjectName: "worker", WithDocker: true}},
+	})
+
+	var collisions *CollisionError
+	if !errors.As(err, &collisions) {
+		t.Fatalf("expected CollisionError, got %v", err)
+	}
+	if len(collisions.Collisions) != 2 {
+		t.Fatalf("expected 2 collisions, got %+v", collisions.Collisions)
+	}
+	first := collisions.Collisions[0]
+	if first.Path != "Dockerfile" || strings.Join(first.Components, ",") != "api,web" {
+		t.Errorf("unexpected collision %+v", first)
+	}
+}
+
+func TestGenerateBatch_Invalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		components []Component
+		wantErr    string
+	}{
+		{"empty", nil, "no components"},
+		{"escaping path", []Component{{Path: "../other", Config: Config{ProjectName: "x"}}}, "escapes"},
+		{"absolute path", []Component{{Path: "/srv", Config: Config{ProjectName: "x"}}}, "relative"},
+		{"invalid config", []Component{{Path: "a", Config: Config{ProjectName: "bad name"}}}, "component 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := GenerateBatch(context.Background(), tt.components)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestGenerateBatch_Progress(t *testing.T) {
+	var updates []Progress
+	ctx := WithProgress(context.Background(), func(p Progress) {
+		updates = append(updates, p)
+	})
+
+	files, err := GenerateBatch(ctx, []Component{
+		{Path: "api", Config: Config{ProjectName: "api", WithDocker: true}},
+		{Path: "web", Config: Config{ProjectName: "web", WithDocker: true}},
+	})
+	if err != nil {
+		t.Fatalf("GenerateBatch failed: %v", err)
+	}
+
+	if len(updates) != len(files) {
+		t.Fatalf("expected %d updates, got %d", len(files), len(updates))
+	}
+	for i, p := range updates {
+		if p.Completed != i+1 || p.Total != len(files) || p.Target != files[i].Path {
+			t.Errorf("update %d = %+v", i, p)
+		}
+	}
+}
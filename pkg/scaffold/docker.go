@@ -1,17 +1,19 @@
 package scaffold
 
+import "context"
+
 // DockerGenerator generates Docker-related files
 type DockerGenerator struct{}
 
 // Ensure DockerGenerator implements Generator
 var _ Generator = (*DockerGenerator)(nil)
 
-func (g *DockerGenerator) Generate(cfg Config) ([]File, error) {
+func (g *DockerGenerator) Generate(ctx context.Context, cfg Config) ([]File, error) {
 	// Use the manifest-driven generator but ensure only Docker files are generated
 	limitedCfg := cfg
 	limitedCfg.WithDocker = true
 	limitedCfg.WithActions = false
 	limitedCfg.WithFlux = false
 
-	return Generate(limitedCfg)
+	return GenerateContext(ctx, limitedCfg)
 }
@@ -1,6 +1,7 @@
 package scaffold
 
 import (
+	"context"
 	"strings"
 	"testing"
 )
@@ -13,7 +14,7 @@ func TestFluxGenerator_Generate(t *testing.T) {
 	}
 
 	// Execute
-	files, err := g.Generate(cfg)
+	files, err := g.Generate(context.Background(), cfg)
 
 	// Verify
 	if err != nil {
@@ -1,6 +1,8 @@
 package scaffold
 
+import "context"
+
 // Generator is the interface that all scaffold generators must implement
 type Generator interface {
-	Generate(cfg Config) ([]File, error)
+	Generate(ctx context.Context, cfg Config) ([]File, error)
 }
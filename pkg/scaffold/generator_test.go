@@ -1,13 +1,14 @@
 package scaffold
 
 import (
+	"context"
 	"testing"
 )
 
 // MockGenerator is a stub for testing the Generator interface
 type MockGenerator struct{}
 
-func (m *MockGenerator) Generate(cfg Config) ([]File, error) {
+func (m *MockGenerator) Generate(ctx context.Context, cfg Config) ([]File, error) {
 	return []File{
 		{Path: "README.md", Content: "# " + cfg.ProjectName},
 	}, nil
@@ -36,7 +37,7 @@ func TestGeneratorInterface(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := &MockGenerator{}
-			got, err := g.Generate(tt.cfg)
+			got, err := g.Generate(context.Background(), tt.cfg)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Generate() error = %v, wantErr %v", err, tt.wantErr)
 				return
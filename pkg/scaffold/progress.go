@@ -0,0 +1,31 @@
+package scaffold
+
+import "context"
+
+// Progress reports that one template has been rendered.
+type Progress struct {
+	Template  string // manifest mapping name
+	Target    string // path of the generated file
+	Completed int    // templates rendered so far, including this one
+	Total     int    // templates selected for this generation
+}
+
+// ProgressFunc receives progress updates during generation. It is called
+// synchronously, so it should return quickly.
+type ProgressFunc func(Progress)
+
+type progressKey struct{}
+
+// WithProgress returns a copy of ctx that makes generators report per-template
+// progress to fn.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// progressFrom returns the ProgressFunc stored in ctx, or a no-op.
+func progressFrom(ctx context.Context) ProgressFunc {
+	if fn, ok := ctx.Value(progressKey{}).(ProgressFunc); ok && fn != nil {
+		return fn
+	}
+	return func(Progress) {}
+}
@@ -0,0 +1,55 @@
+package scaffold
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestGenerateContext_Progress(t *testing.T) {
+	var updates []Progress
+	ctx := WithProgress(context.Background(), func(p Progress) {
+		updates = append(updates, p)
+	})
+
+	files, err := GenerateContext(ctx, Config{
+		ProjectName: "progress-app",
+		WithActions: true,
+		WithDocker:  true,
+	})
+	if err != nil {
+		t.Fatalf("GenerateContext failed: %v", err)
+	}
+
+	if len(updates) != len(files) {
+		t.Fatalf("got %d progress updates for %d files", len(updates), len(files))
+	}
+	for i, p := range updates {
+		if p.Completed != i+1 || p.Total != len(files) {
+			t.Errorf("update %d = %d/%d, want %d/%d", i, p.Completed, p.Total, i+1, len(files))
+		}
+		if p.Target != files[i].Path {
+			t.Errorf("update %d target = %s, want %s", i, p.Target, files[i].Path)
+		}
+	}
+}
+
+func TestGenerateContext_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Cancel after the first template so generation stops midway.
+	ctx = WithProgress(ctx, func(Progress) { cancel() })
+
+	files, err := NewProjectGenerator().Generate(ctx, Config{
+		ProjectName: "cancel-app",
+		WithActions: true,
+		WithDocker:  true,
+		WithFlux:    true,
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if files != nil {
+		t.Errorf("expected no files after cancellation, got %d", len(files))
+	}
+}
@@ -1,5 +1,7 @@
 package scaffold
 
+import "context"
+
 // ProjectGenerator orchestrates the generation of the entire project
 type ProjectGenerator struct {
 	Actions Generator
@@ -19,6 +21,6 @@ func NewProjectGenerator() *ProjectGenerator {
 // Ensure ProjectGenerator implements Generator
 var _ Generator = (*ProjectGenerator)(nil)
 
-func (g *ProjectGenerator) Generate(cfg Config) ([]File, error) {
-	return Generate(cfg)
+func (g *ProjectGenerator) Generate(ctx context.Context, cfg Config) ([]File, error) {
+	return GenerateContext(ctx, cfg)
 }
@@ -1,6 +1,7 @@
 package scaffold
 
 import (
+	"context"
 	"testing"
 )
 
@@ -49,7 +50,7 @@ func TestProjectGenerator_Generate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			files, err := g.Generate(tt.cfg)
+			files, err := g.Generate(context.Background(), tt.cfg)
 			if err != nil {
 				t.Fatalf("Generate failed: %v", err)
 			}
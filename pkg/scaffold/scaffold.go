@@ -1,42 +1,84 @@
 package scaffold
 
 import (
+	"context"
+	"crypto/sha256"
 	"fmt"
+	"time"
 
+	"github.com/modelcontextprotocol/platform.mcp/internal/logging"
 	"github.com/modelcontextprotocol/platform.mcp/internal/templates"
 )
 
 // Generate returns a slice of File structs with the generated content.
 func Generate(cfg Config) ([]File, error) {
+	return GenerateContext(context.Background(), cfg)
+}
+
+// GenerateContext is like Generate but stops as soon as ctx is cancelled and
+// reports per-template progress to the ProgressFunc attached with WithProgress.
+// It logs to the logger carried by ctx, if any.
+func GenerateContext(ctx context.Context, cfg Config) ([]File, error) {
+	logger := logging.FromContext(ctx).With("project", cfg.ProjectName)
+	start := time.Now()
+
 	if err := ValidateConfig(cfg); err != nil {
+		logger.Warn("config validation failed", "error", err)
 		return nil, err
 	}
 
-	manifest, err := templates.GetManifest()
+	// A reload during the run must not mix the manifest of one snapshot with
+	// the templates of another.
+	snapshot := templates.Active()
+	manifest, err := snapshot.Manifest()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get template manifest: %w", err)
 	}
 
 	mappings := FilterTemplates(manifest, cfg)
+	progress := progressFrom(ctx)
+	logger.Debug("templates selected", "count", len(mappings), "available", len(manifest.Templates))
 
 	var files []File
-	for _, mapping := range mappings {
-		tmplContent, err := templates.Load(mapping.Source)
+	for i, mapping := range mappings {
+		if err := ctx.Err(); err != nil {
+			logger.Info("generation cancelled", "completed", i, "total", len(mappings))
+			return nil, fmt.Errorf("generation cancelled: %w", err)
+		}
+
+		renderStart := time.Now()
+		tmplContent, layer, err := snapshot.Locate(mapping.Source)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load template %s: %w", mapping.Source, err)
 		}
+		logger.Debug("template resolved", "template", mapping.Name, "source", mapping.Source, "layer", layer)
 
 		rendered, err := templates.Render(tmplContent, cfg)
 		if err != nil {
 			return nil, fmt.Errorf("failed to render template %s: %w", mapping.Name, err)
 		}
+		logger.Debug("template rendered", "template", mapping.Name, "target", mapping.Target, "duration", time.Since(renderStart))
 
 		files = append(files, File{
 			Path:    mapping.Target,
 			Content: rendered,
 			Mode:    0644,
+			Template: &TemplateSource{
+				Name:   mapping.Name,
+				Source: mapping.Source,
+				Layer:  layer,
+				SHA256: fmt.Sprintf("%x", sha256.Sum256([]byte(tmplContent))),
+			},
+		})
+
+		progress(Progress{
+			Template:  mapping.Name,
+			Target:    mapping.Target,
+			Completed: i + 1,
+			Total:     len(mappings),
 		})
 	}
 
+	logger.Info("generation complete", "files", len(files), "duration", time.Since(start))
 	return files, nil
 }
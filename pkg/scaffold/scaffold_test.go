@@ -122,6 +122,17 @@ func TestValidateConfig(t *testing.T) {
 		{"Empty Name", Config{ProjectName: "", WorkflowType: "go"}, true},
 		{"Invalid Name", Config{ProjectName: "Invalid Name!", WorkflowType: "go"}, true},
 		{"Unsupported Type", Config{ProjectName: "valid", WorkflowType: "ruby"}, true},
+		{"All Options", Config{ProjectName: "api", WorkflowType: "python", Org: "acme", Registry: "registry.example.com:5000/team", Branch: "release/v1"}, false},
+		{"Invalid Org", Config{ProjectName: "api", Org: "acme/inc"}, true},
+		{"Registry With Scheme", Config{ProjectName: "api", Registry: "https://ghcr.io"}, true},
+		{"Upper-case Registry", Config{ProjectName: "api", Registry: "GHCR.io"}, true},
+		{"Branch With Spaces", Config{ProjectName: "api", Branch: "my branch"}, true},
+		{"Branch With Dots", Config{ProjectName: "api", Branch: "a..b"}, true},
+		{"Environments", Config{ProjectName: "api", Environments: []string{"staging", "prod-eu"}}, false},
+		{"Invalid Environment", Config{ProjectName: "api", Environments: []string{"Prod"}}, true},
+		{"Duplicate Environment", Config{ProjectName: "api", Environments: []string{"prod", "prod"}}, true},
+		{"Variables", Config{ProjectName: "api", Variables: map[string]string{"team_name": "payments"}}, false},
+		{"Invalid Variable", Config{ProjectName: "api", Variables: map[string]string{"team-name": "payments"}}, true},
 	}
 
 	for _, tt := range tests {
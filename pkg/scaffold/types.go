@@ -2,21 +2,48 @@ package scaffold
 
 import "fmt"
 
+// DefaultWorkflowType is the workflow type used when none is specified.
+const DefaultWorkflowType = "go"
+
+// WorkflowTypes lists the accepted WorkflowType values. "node" is an alias for "typescript".
+var WorkflowTypes = []string{"go", "typescript", "python", "node"}
+
 // File represents a generated file.
 type File struct {
 	Path    string
 	Content string
 	Mode    uint32
+	// Template is the template the file was rendered from, or nil if it was
+	// not rendered from a manifest mapping.
+	Template *TemplateSource
+}
+
+// TemplateSource identifies the template a file was rendered from.
+type TemplateSource struct {
+	Name   string // manifest mapping name
+	Source string // template file name
+	Layer  string // external directory it was read from, or "embedded"
+	SHA256 string // hex digest of the template content
 }
 
 // Config represents the generation options.
 type Config struct {
-	ProjectName  string
-	UseDocker    bool
-	WorkflowType string // "go", "typescript", "python"
-	WithActions  bool
-	WithDocker   bool
-	WithFlux     bool
+	ProjectName  string            `json:"project_name" jsonschema:"Name of the project. Alphanumeric and hyphens only; used in workflow names and build paths."`
+	UseDocker    bool              `json:"use_docker,omitempty" jsonschema:"Legacy switch that also enables the Docker templates."`
+	WorkflowType string            `json:"workflow_type,omitempty" jsonschema:"Language of the CI workflow generated when with_actions is set."` // "go", "typescript", "python"
+	WithActions  bool              `json:"with_actions,omitempty" jsonschema:"Generate GitHub Actions workflows: a generic CI workflow plus one for workflow_type."`
+	WithDocker   bool              `json:"with_docker,omitempty" jsonschema:"Generate a Dockerfile and a Docker build workflow."`
+	WithFlux     bool              `json:"with_flux,omitempty" jsonschema:"Generate Flux CD manifests."`
+	Org          string            `json:"org,omitempty" jsonschema:"GitHub organization that owns the repository, used in Flux source URLs; myorg when empty."`
+	Registry     string            `json:"registry,omitempty" jsonschema:"Container registry host (and optional path) to push images to, e.g. ghcr.io. When empty, images are built but not pushed."`
+	Branch       string            `json:"branch,omitempty" jsonschema:"Default branch: workflows run on pushes to it and Flux tracks it. When empty, workflows run on every push and Flux tracks main."`
+	Environments []string          `json:"environments,omitempty" jsonschema:"Deployment environments, e.g. staging and production. Flux gets one Kustomization per environment, reading deploy/<environment>; when empty, a single one reads deploy/."`
+	Variables    map[string]string `json:"variables,omitempty" jsonschema:"Extra values for custom templates, available as {{ .Variables.<name> }}. Names are letters, digits and underscores, not starting with a digit."`
+}
+
+// DefaultConfig returns the configuration defaults applied by the CLI and MCP tools.
+func DefaultConfig() Config {
+	return Config{WorkflowType: DefaultWorkflowType}
 }
 
 // Validate checks if the configuration is valid
@@ -2,10 +2,20 @@ package scaffold
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
+	"slices"
+	"strings"
 )
 
-var projectNameRegex = regexp.MustCompile(`^[a-zA-Z0-9-]+$`)
+var (
+	projectNameRegex = regexp.MustCompile(`^[a-zA-Z0-9-]+$`)
+	orgRegex         = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9-]*$`)
+	registryRegex    = regexp.MustCompile(`^[a-z0-9]([a-z0-9.-]*[a-z0-9])?(:[0-9]+)?(/[a-z0-9._-]+)*$`)
+	branchRegex      = regexp.MustCompile(`^[a-zA-Z0-9._/-]+$`)
+	environmentRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+	variableRegex    = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
 
 // ValidateConfig checks if the configuration is valid.
 func ValidateConfig(cfg Config) error {
@@ -17,12 +27,36 @@ func ValidateConfig(cfg Config) error {
 		return errors.New("project name must be alphanumeric (hyphens allowed)")
 	}
 
-	switch cfg.WorkflowType {
-	case "go", "typescript", "python", "node", "":
-		// Valid
-	default:
+	if cfg.WorkflowType != "" && !slices.Contains(WorkflowTypes, cfg.WorkflowType) {
 		return errors.New("unsupported workflow type")
 	}
 
+	if cfg.Org != "" && !orgRegex.MatchString(cfg.Org) {
+		return errors.New("org must be alphanumeric (hyphens allowed)")
+	}
+
+	if cfg.Registry != "" && !registryRegex.MatchString(cfg.Registry) {
+		return errors.New("registry must be a lower-case host name with an optional port and path, e.g. ghcr.io or registry.example.com:5000/team")
+	}
+
+	if cfg.Branch != "" && (!branchRegex.MatchString(cfg.Branch) || strings.Contains(cfg.Branch, "..")) {
+		return errors.New("branch must be a valid git branch name")
+	}
+
+	for i, env := range cfg.Environments {
+		if !environmentRegex.MatchString(env) {
+			return fmt.Errorf("environment %q must be lower-case alphanumeric (hyphens allowed)", env)
+		}
+		if slices.Contains(cfg.Environments[:i], env) {
+			return fmt.Errorf("environment %q is listed twice", env)
+		}
+	}
+
+	for name := range cfg.Variables {
+		if !variableRegex.MatchString(name) {
+			return fmt.Errorf("variable name %q must be letters, digits and underscores, not starting with a digit", name)
+		}
+	}
+
 	return nil
 }